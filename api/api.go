@@ -7,7 +7,7 @@ import (
 	"time"
 )
 
-//go:generate mockgen -package api -destination mock.go github.com/evcc-io/evcc/api Charger,ChargeState,CurrentLimiter,PhaseSwitcher,Identifier,Meter,MeterEnergy,Vehicle,ChargeRater,Battery,Tariff,BatteryController
+//go:generate mockgen -package api -destination mock.go github.com/evcc-io/evcc/api Charger,ChargeState,CurrentLimiter,PhaseSwitcher,Identifier,Meter,MeterEnergy,Vehicle,ChargeRater,Battery,Tariff,BatteryController,VoltageController,ChargeTimer,Reconnectable,VehicleCurrentLimit
 
 // Meter provides total active power in W
 type Meter interface {
@@ -82,6 +82,16 @@ type PhaseSwitcher interface {
 	Phases1p3p(phases int) error
 }
 
+// Temperature provides charger or cable temperature in °C
+type Temperature interface {
+	Temperature() (float64, error)
+}
+
+// VoltageController requests a supply voltage, e.g. from a smart grid transformer
+type VoltageController interface {
+	SetVoltage(voltage float64) error
+}
+
 // Diagnosis is a helper interface that allows to dump diagnostic data to console
 type Diagnosis interface {
 	Diagnose()
@@ -156,12 +166,28 @@ type CurrentLimiter interface {
 	GetMinMaxCurrent() (float64, float64, error)
 }
 
+// FrequencyMeter provides the grid frequency in Hz
+type FrequencyMeter interface {
+	Frequency() (float64, error)
+}
+
+// SocEstimate is implemented by vehicles without api whose soc is estimated from the charge power
+type SocEstimate interface {
+	SetSoc(soc float64)
+	ResetSoc()
+}
+
 // SocLimiter returns the soc limit
 type SocLimiter interface {
 	// TODO rename LimitSoc
 	TargetSoc() (float64, error)
 }
 
+// VehicleCurrentLimit returns the charge current limit configured in the vehicle
+type VehicleCurrentLimit interface {
+	ChargeCurrentLimit() (int64, error)
+}
+
 // VehicleChargeController allows to start/stop the charging session on the vehicle side
 type VehicleChargeController interface {
 	StartCharge() error
@@ -173,6 +199,11 @@ type Resurrector interface {
 	WakeUp() error
 }
 
+// Reconnectable re-establishes the device connection without restarting
+type Reconnectable interface {
+	Reconnect() error
+}
+
 // Tariff is a tariff capable of retrieving tariff rates
 type Tariff interface {
 	Rates() (Rates, error)
@@ -17,6 +17,16 @@ func ChargeModeString(mode string) (ChargeMode, error) {
 		return ModeMinPV, nil
 	case string(ModePV):
 		return ModePV, nil
+	case string(ModeGreenCharge):
+		return ModeGreenCharge, nil
+	case string(ModeScheduled):
+		return ModeScheduled, nil
+	case string(ModeCheap):
+		return ModeCheap, nil
+	case string(ModeGridFriendly):
+		return ModeGridFriendly, nil
+	case string(ModeOffPeak):
+		return ModeOffPeak, nil
 	case string(ModeOff):
 		return ModeOff, nil
 	default:
@@ -5,16 +5,21 @@ import (
 	"strings"
 )
 
-// ChargeMode is the charge operation mode. Valid values are off, now, minpv and pv
+// ChargeMode is the charge operation mode. Valid values are off, now, minpv, pv, green, scheduled, cheap, gridfriendly and offpeak
 type ChargeMode string
 
 // Charge modes
 const (
-	ModeEmpty ChargeMode = ""
-	ModeOff   ChargeMode = "off"
-	ModeNow   ChargeMode = "now"
-	ModeMinPV ChargeMode = "minpv"
-	ModePV    ChargeMode = "pv"
+	ModeEmpty        ChargeMode = ""
+	ModeOff          ChargeMode = "off"
+	ModeNow          ChargeMode = "now"
+	ModeMinPV        ChargeMode = "minpv"
+	ModePV           ChargeMode = "pv"
+	ModeGreenCharge  ChargeMode = "green"
+	ModeScheduled    ChargeMode = "scheduled"
+	ModeCheap        ChargeMode = "cheap"
+	ModeGridFriendly ChargeMode = "gridfriendly"
+	ModeOffPeak      ChargeMode = "offpeak"
 )
 
 // String implements Stringer
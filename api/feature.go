@@ -18,4 +18,5 @@ const (
 	IntegratedDevice
 	Heating
 	Retryable
+	RapidCurrent // charger applies current and enable changes within seconds
 )
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/evcc-io/evcc/api (interfaces: Charger,ChargeState,CurrentLimiter,PhaseSwitcher,Identifier,Meter,MeterEnergy,Vehicle,ChargeRater,Battery,Tariff,BatteryController)
+// Source: github.com/evcc-io/evcc/api (interfaces: Charger,ChargeState,CurrentLimiter,PhaseSwitcher,Identifier,Meter,MeterEnergy,Vehicle,ChargeRater,Battery,Tariff,BatteryController,VoltageController,ChargeTimer,Reconnectable,VehicleCurrentLimit)
 //
 // Generated by this command:
 //
-//	mockgen -package api -destination mock.go github.com/evcc-io/evcc/api Charger,ChargeState,CurrentLimiter,PhaseSwitcher,Identifier,Meter,MeterEnergy,Vehicle,ChargeRater,Battery,Tariff,BatteryController
+//	mockgen -package api -destination mock.go github.com/evcc-io/evcc/api Charger,ChargeState,CurrentLimiter,PhaseSwitcher,Identifier,Meter,MeterEnergy,Vehicle,ChargeRater,Battery,Tariff,BatteryController,VoltageController,ChargeTimer,Reconnectable,VehicleCurrentLimit
 //
 
 // Package api is a generated GoMock package.
@@ -11,6 +11,7 @@ package api
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -636,3 +637,153 @@ func (mr *MockBatteryControllerMockRecorder) SetBatteryMode(arg0 any) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBatteryMode", reflect.TypeOf((*MockBatteryController)(nil).SetBatteryMode), arg0)
 }
+
+// MockVoltageController is a mock of VoltageController interface.
+type MockVoltageController struct {
+	ctrl     *gomock.Controller
+	recorder *MockVoltageControllerMockRecorder
+}
+
+// MockVoltageControllerMockRecorder is the mock recorder for MockVoltageController.
+type MockVoltageControllerMockRecorder struct {
+	mock *MockVoltageController
+}
+
+// NewMockVoltageController creates a new mock instance.
+func NewMockVoltageController(ctrl *gomock.Controller) *MockVoltageController {
+	mock := &MockVoltageController{ctrl: ctrl}
+	mock.recorder = &MockVoltageControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVoltageController) EXPECT() *MockVoltageControllerMockRecorder {
+	return m.recorder
+}
+
+// SetVoltage mocks base method.
+func (m *MockVoltageController) SetVoltage(arg0 float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVoltage", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetVoltage indicates an expected call of SetVoltage.
+func (mr *MockVoltageControllerMockRecorder) SetVoltage(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVoltage", reflect.TypeOf((*MockVoltageController)(nil).SetVoltage), arg0)
+}
+
+// MockChargeTimer is a mock of ChargeTimer interface.
+type MockChargeTimer struct {
+	ctrl     *gomock.Controller
+	recorder *MockChargeTimerMockRecorder
+}
+
+// MockChargeTimerMockRecorder is the mock recorder for MockChargeTimer.
+type MockChargeTimerMockRecorder struct {
+	mock *MockChargeTimer
+}
+
+// NewMockChargeTimer creates a new mock instance.
+func NewMockChargeTimer(ctrl *gomock.Controller) *MockChargeTimer {
+	mock := &MockChargeTimer{ctrl: ctrl}
+	mock.recorder = &MockChargeTimerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChargeTimer) EXPECT() *MockChargeTimerMockRecorder {
+	return m.recorder
+}
+
+// ChargingTime mocks base method.
+func (m *MockChargeTimer) ChargingTime() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChargingTime")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChargingTime indicates an expected call of ChargingTime.
+func (mr *MockChargeTimerMockRecorder) ChargingTime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargingTime", reflect.TypeOf((*MockChargeTimer)(nil).ChargingTime))
+}
+
+// MockReconnectable is a mock of Reconnectable interface.
+type MockReconnectable struct {
+	ctrl     *gomock.Controller
+	recorder *MockReconnectableMockRecorder
+}
+
+// MockReconnectableMockRecorder is the mock recorder for MockReconnectable.
+type MockReconnectableMockRecorder struct {
+	mock *MockReconnectable
+}
+
+// NewMockReconnectable creates a new mock instance.
+func NewMockReconnectable(ctrl *gomock.Controller) *MockReconnectable {
+	mock := &MockReconnectable{ctrl: ctrl}
+	mock.recorder = &MockReconnectableMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReconnectable) EXPECT() *MockReconnectableMockRecorder {
+	return m.recorder
+}
+
+// Reconnect mocks base method.
+func (m *MockReconnectable) Reconnect() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconnect")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reconnect indicates an expected call of Reconnect.
+func (mr *MockReconnectableMockRecorder) Reconnect() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconnect", reflect.TypeOf((*MockReconnectable)(nil).Reconnect))
+}
+
+// MockVehicleCurrentLimit is a mock of VehicleCurrentLimit interface.
+type MockVehicleCurrentLimit struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleCurrentLimitMockRecorder
+}
+
+// MockVehicleCurrentLimitMockRecorder is the mock recorder for MockVehicleCurrentLimit.
+type MockVehicleCurrentLimitMockRecorder struct {
+	mock *MockVehicleCurrentLimit
+}
+
+// NewMockVehicleCurrentLimit creates a new mock instance.
+func NewMockVehicleCurrentLimit(ctrl *gomock.Controller) *MockVehicleCurrentLimit {
+	mock := &MockVehicleCurrentLimit{ctrl: ctrl}
+	mock.recorder = &MockVehicleCurrentLimitMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleCurrentLimit) EXPECT() *MockVehicleCurrentLimitMockRecorder {
+	return m.recorder
+}
+
+// ChargeCurrentLimit mocks base method.
+func (m *MockVehicleCurrentLimit) ChargeCurrentLimit() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChargeCurrentLimit")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChargeCurrentLimit indicates an expected call of ChargeCurrentLimit.
+func (mr *MockVehicleCurrentLimitMockRecorder) ChargeCurrentLimit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeCurrentLimit", reflect.TypeOf((*MockVehicleCurrentLimit)(nil).ChargeCurrentLimit))
+}
@@ -143,6 +143,15 @@ func NewBenderCC(uri string, id uint8) (api.Charger, error) {
 	return decorateBenderCC(wb, currentPower, currents, voltages, chargedEnergy, totalEnergy, identify), nil
 }
 
+var _ api.Reconnectable = (*BenderCC)(nil)
+
+// Reconnect implements the api.Reconnectable interface
+func (wb *BenderCC) Reconnect() error {
+	wb.conn.Close()
+	_, err := wb.conn.ReadHoldingRegisters(bendRegChargePointState, 1)
+	return err
+}
+
 // Status implements the api.Charger interface
 func (wb *BenderCC) Status() (api.ChargeStatus, error) {
 	b, err := wb.conn.ReadHoldingRegisters(bendRegChargePointState, 1)
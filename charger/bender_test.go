@@ -0,0 +1,151 @@
+package charger
+
+import (
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/andig/mbserver"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util/modbus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registerHandler serves canned holding register values
+type registerHandler struct {
+	mbserver.RequestHandler
+	mu   sync.Mutex
+	regs map[uint16]uint16
+}
+
+func (h *registerHandler) HandleHoldingRegisters(req *mbserver.HoldingRegistersRequest) ([]uint16, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if req.IsWrite {
+		for i, v := range req.Args {
+			h.regs[req.Addr+uint16(i)] = v
+		}
+		return nil, nil
+	}
+
+	res := make([]uint16, req.Quantity)
+	for i := range res {
+		res[i] = h.regs[req.Addr+uint16(i)]
+	}
+
+	return res, nil
+}
+
+func (h *registerHandler) set(addr uint16, values ...uint16) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, v := range values {
+		h.regs[addr+uint16(i)] = v
+	}
+}
+
+func (h *registerHandler) get(addr uint16) uint16 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.regs[addr]
+}
+
+func TestBenderCC(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	h := &registerHandler{
+		RequestHandler: new(mbserver.DummyHandler),
+		regs:           make(map[uint16]uint16),
+	}
+
+	srv, _ := mbserver.New(h)
+	require.NoError(t, srv.Start(l))
+	defer func() { _ = srv.Stop() }()
+
+	conn, err := modbus.NewConnection(l.Addr().String(), "", "", 0, modbus.Tcp, 255)
+	require.NoError(t, err)
+
+	wb := &BenderCC{conn: conn, current: 6}
+
+	// control pilot state
+	for state, expected := range map[uint16]api.ChargeStatus{1: api.StatusA, 2: api.StatusB, 3: api.StatusC, 4: api.StatusC} {
+		h.set(bendRegChargePointState, state)
+		res, err := wb.Status()
+		require.NoError(t, err)
+		assert.Equal(t, expected, res)
+	}
+
+	h.set(bendRegChargePointState, 5)
+	_, err = wb.Status()
+	assert.Error(t, err)
+
+	// current setpoint and enable
+	require.NoError(t, wb.MaxCurrent(16))
+	assert.Equal(t, uint16(16), h.get(bendRegHemsCurrentLimit))
+	assert.Error(t, wb.MaxCurrent(5))
+
+	require.NoError(t, wb.Enable(false))
+	assert.Equal(t, uint16(0), h.get(bendRegHemsCurrentLimit))
+
+	enabled, err := wb.Enabled()
+	require.NoError(t, err)
+	assert.False(t, enabled)
+
+	require.NoError(t, wb.Enable(true))
+	assert.Equal(t, uint16(16), h.get(bendRegHemsCurrentLimit))
+
+	// metering
+	h.set(bendRegCurrents, 0, 16000, 0, 15900, 0xffff, 0xffff) // L3 not available
+	h.set(bendRegTotalEnergy, 0x0001, 0xe240)                  // 123456 Wh
+	h.set(bendRegActivePower, 0, 7317)
+
+	l1, l2, l3, err := wb.currents()
+	require.NoError(t, err)
+	assert.Equal(t, []float64{16, 15.9, 0}, []float64{l1, l2, l3})
+
+	power, err := wb.currentPower()
+	require.NoError(t, err)
+	assert.Equal(t, 7317.0, power)
+
+	energy, err := wb.totalEnergy()
+	require.NoError(t, err)
+	assert.Equal(t, 123.456, energy)
+
+	// legacy register set sums phase energies
+	wb.legacy = true
+	h.set(bendRegPhaseEnergy, 0, 1000, 0, 2000, 0, 3000)
+
+	energy, err = wb.totalEnergy()
+	require.NoError(t, err)
+	assert.Equal(t, 6.0, energy)
+}
+
+func TestBenderCCReconnect(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	h := &registerHandler{
+		RequestHandler: new(mbserver.DummyHandler),
+		regs:           make(map[uint16]uint16),
+	}
+
+	srv, _ := mbserver.New(h)
+	require.NoError(t, srv.Start(l))
+
+	conn, err := modbus.NewConnection(l.Addr().String(), "", "", 0, modbus.Tcp, 255)
+	require.NoError(t, err)
+
+	wb := &BenderCC{conn: conn, current: 6}
+	require.NoError(t, wb.Reconnect())
+
+	// unreachable charger
+	require.NoError(t, srv.Stop())
+	assert.Error(t, wb.Reconnect())
+}
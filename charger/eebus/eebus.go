@@ -42,6 +42,7 @@ type EEBus struct {
 	SKI string
 
 	clients map[string]EEBusClientCBs
+	evses   map[string]*emobility.EMobilityImpl
 }
 
 var Instance *EEBus
@@ -113,6 +114,7 @@ func NewServer(other map[string]interface{}) (*EEBus, error) {
 	c := &EEBus{
 		log:     log,
 		clients: make(map[string]EEBusClientCBs),
+		evses:   make(map[string]*emobility.EMobilityImpl),
 		SKI:     ski,
 	}
 
@@ -127,10 +129,15 @@ func NewServer(other map[string]interface{}) (*EEBus, error) {
 	return c, nil
 }
 
-func (c *EEBus) RegisterEVSE(ski, ip string, connectHandler func(string), disconnectHandler func(string), dataProvider emobility.EmobilityDataProvider) *emobility.EMobilityImpl {
+// normalizeSki removes separators from the ski
+func normalizeSki(ski string) string {
 	ski = strings.ReplaceAll(ski, "-", "")
 	ski = strings.ReplaceAll(ski, " ", "")
-	ski = strings.ToLower(ski)
+	return strings.ToLower(ski)
+}
+
+func (c *EEBus) RegisterEVSE(ski, ip string, connectHandler func(string), disconnectHandler func(string), dataProvider emobility.EmobilityDataProvider) *emobility.EMobilityImpl {
+	ski = normalizeSki(ski)
 	c.log.TRACE.Printf("registering ski: %s", ski)
 
 	if ski == c.SKI {
@@ -144,7 +151,23 @@ func (c *EEBus) RegisterEVSE(ski, ip string, connectHandler func(string), discon
 	defer c.mux.Unlock()
 	c.clients[ski] = EEBusClientCBs{onConnect: connectHandler, onDisconnect: disconnectHandler}
 
-	return c.Cem.RegisterEmobilityRemoteDevice(serviceDetails, dataProvider)
+	evse := c.Cem.RegisterEmobilityRemoteDevice(serviceDetails, dataProvider)
+	c.evses[ski] = evse
+
+	return evse
+}
+
+// EVSE returns the emobility use case of an already registered EVSE
+func (c *EEBus) EVSE(ski string) (*emobility.EMobilityImpl, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	evse, ok := c.evses[normalizeSki(ski)]
+	if !ok {
+		return nil, fmt.Errorf("evse not registered: %s", ski)
+	}
+
+	return evse, nil
 }
 
 func (c *EEBus) Run() {
@@ -0,0 +1,15 @@
+package charger
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/charger/modbus"
+)
+
+func init() {
+	registry.Add("modbus", NewModbusFromConfig)
+}
+
+// NewModbusFromConfig creates a generic modbus charger from generic config
+func NewModbusFromConfig(other map[string]interface{}) (api.Charger, error) {
+	return modbus.NewFromConfig(other)
+}
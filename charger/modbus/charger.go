@@ -0,0 +1,198 @@
+package modbus
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/modbus"
+)
+
+// Registers is the register map of a generic IEC 61851 charger.
+// Status, current and meter registers are 32 bit unsigned big endian values.
+type Registers struct {
+	Status  uint16 // charging state, IEC 61851 state code in the high byte of the low word
+	Enable  uint16 // session start/stop, 16 bit, see EnableValue and DisableValue
+	Current uint16 // charge current setpoint in mA
+	Power   uint16 // active power in W
+	Energy  uint16 // session energy in Wh
+
+	EnableValue, DisableValue uint16
+}
+
+// Config is the generic modbus charger configuration.
+// Required registers are pointers since 0 is a valid register address.
+type Config struct {
+	modbus.Settings           `mapstructure:",squash"`
+	Status, Enable, Current   *uint16
+	Power, Energy             uint16
+	EnableValue, DisableValue uint16
+}
+
+// Charger is a generic modbus charger implementation
+type Charger struct {
+	mu      sync.Mutex
+	conn    *modbus.Connection
+	clock   clock.Clock
+	regs    Registers
+	started time.Time
+}
+
+// NewFromConfig creates a generic modbus charger from generic config
+func NewFromConfig(other map[string]interface{}) (*Charger, error) {
+	cc := Config{
+		Settings: modbus.Settings{
+			ID: 1,
+		},
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	// there is no default register map, device specific maps are provided by the dedicated chargers
+	if cc.Status == nil || cc.Enable == nil || cc.Current == nil {
+		return nil, errors.New("missing status, enable or current register")
+	}
+
+	conn, err := modbus.NewConnection(cc.URI, cc.Device, cc.Comset, cc.Baudrate, modbus.ProtocolFromRTU(cc.RTU), cc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	log := util.NewLogger("modbus")
+	conn.Logger(log.TRACE)
+
+	return New(conn, Registers{
+		Status:       *cc.Status,
+		Enable:       *cc.Enable,
+		Current:      *cc.Current,
+		Power:        cc.Power,
+		Energy:       cc.Energy,
+		EnableValue:  cc.EnableValue,
+		DisableValue: cc.DisableValue,
+	}), nil
+}
+
+// New creates a generic modbus charger using the given connection and register map
+func New(conn *modbus.Connection, regs Registers) *Charger {
+	return &Charger{
+		conn:  conn,
+		clock: clock.New(),
+		regs:  regs,
+	}
+}
+
+func (wb *Charger) readUint32(reg uint16) (uint32, error) {
+	b, err := wb.conn.ReadHoldingRegisters(reg, 2)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint32(b), nil
+}
+
+// Status implements the api.Charger interface
+func (wb *Charger) Status() (api.ChargeStatus, error) {
+	v, err := wb.readUint32(wb.regs.Status)
+	if err != nil {
+		return api.StatusNone, err
+	}
+
+	var res api.ChargeStatus
+
+	switch s := v >> 8 & 0x7f; s {
+	case 0: // State A: Idle
+		res = api.StatusA
+	case 1, 2, 3: // State B1, B2, C1: EV plugged in, not charging
+		res = api.StatusB
+	case 4: // State C2: Charging
+		res = api.StatusC
+	case 5: // Session stopped, EV still plugged in
+		res = api.StatusB
+	default:
+		return api.StatusNone, fmt.Errorf("invalid status: %0x", s)
+	}
+
+	wb.mu.Lock()
+	defer wb.mu.Unlock()
+
+	switch {
+	case res == api.StatusA:
+		wb.started = time.Time{}
+	case wb.started.IsZero():
+		wb.started = wb.clock.Now()
+	}
+
+	return res, nil
+}
+
+// Enabled implements the api.Charger interface
+func (wb *Charger) Enabled() (bool, error) {
+	b, err := wb.conn.ReadHoldingRegisters(wb.regs.Enable, 1)
+	if err != nil {
+		return false, err
+	}
+
+	return binary.BigEndian.Uint16(b) == wb.regs.EnableValue, nil
+}
+
+// Enable implements the api.Charger interface
+func (wb *Charger) Enable(enable bool) error {
+	v := wb.regs.DisableValue
+	if enable {
+		v = wb.regs.EnableValue
+	}
+
+	_, err := wb.conn.WriteSingleRegister(wb.regs.Enable, v)
+	return err
+}
+
+// MaxCurrent implements the api.Charger interface
+func (wb *Charger) MaxCurrent(current int64) error {
+	if current < 6 {
+		return fmt.Errorf("invalid current %d", current)
+	}
+
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(current*1e3))
+
+	_, err := wb.conn.WriteMultipleRegisters(wb.regs.Current, 2, b)
+	return err
+}
+
+var _ api.Meter = (*Charger)(nil)
+
+// CurrentPower implements the api.Meter interface
+func (wb *Charger) CurrentPower() (float64, error) {
+	v, err := wb.readUint32(wb.regs.Power)
+	return float64(v), err
+}
+
+var _ api.ChargeRater = (*Charger)(nil)
+
+// ChargedEnergy implements the api.ChargeRater interface
+func (wb *Charger) ChargedEnergy() (float64, error) {
+	v, err := wb.readUint32(wb.regs.Energy)
+	return float64(v) / 1e3, err
+}
+
+var _ api.ChargeTimer = (*Charger)(nil)
+
+// ChargingTime implements the api.ChargeTimer interface.
+// The session duration is measured from the first status poll with a vehicle connected.
+func (wb *Charger) ChargingTime() (time.Duration, error) {
+	wb.mu.Lock()
+	defer wb.mu.Unlock()
+
+	if wb.started.IsZero() {
+		return 0, nil
+	}
+
+	return wb.clock.Since(wb.started), nil
+}
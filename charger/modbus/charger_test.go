@@ -0,0 +1,176 @@
+package modbus
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andig/mbserver"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util/modbus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registerHandler serves holding registers from memory
+type registerHandler struct {
+	mbserver.RequestHandler
+	mu   sync.Mutex
+	regs map[uint16]uint16
+}
+
+func (h *registerHandler) HandleHoldingRegisters(req *mbserver.HoldingRegistersRequest) ([]uint16, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if req.IsWrite {
+		for i, v := range req.Args {
+			h.regs[req.Addr+uint16(i)] = v
+		}
+		return nil, nil
+	}
+
+	res := make([]uint16, req.Quantity)
+	for i := range res {
+		res[i] = h.regs[req.Addr+uint16(i)]
+	}
+
+	return res, nil
+}
+
+func (h *registerHandler) set(addr uint16, values ...uint16) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, v := range values {
+		h.regs[addr+uint16(i)] = v
+	}
+}
+
+func (h *registerHandler) get(addr uint16) uint16 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.regs[addr]
+}
+
+func TestCharger(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	h := &registerHandler{
+		RequestHandler: new(mbserver.DummyHandler),
+		regs:           make(map[uint16]uint16),
+	}
+
+	srv, _ := mbserver.New(h)
+	require.NoError(t, srv.Start(l))
+	defer func() { _ = srv.Stop() }()
+
+	conn, err := modbus.NewConnection(l.Addr().String(), "", "", 0, modbus.Tcp, 1)
+	require.NoError(t, err)
+
+	regs := Registers{
+		Status:       0x100,
+		Enable:       0x200,
+		Current:      0x300,
+		Power:        0x400,
+		Energy:       0x500,
+		EnableValue:  0,
+		DisableValue: 1,
+	}
+	clck := clock.NewMock()
+
+	wb := New(conn, regs)
+	wb.clock = clck
+
+	// charging state
+	for state, expected := range map[uint16]api.ChargeStatus{0: api.StatusA, 1: api.StatusB, 2: api.StatusB, 3: api.StatusB, 4: api.StatusC, 5: api.StatusB} {
+		h.set(regs.Status, 0, state<<8)
+		res, err := wb.Status()
+		require.NoError(t, err)
+		assert.Equal(t, expected, res, state)
+	}
+
+	h.set(regs.Status, 0, 9<<8)
+	_, err = wb.Status()
+	assert.Error(t, err)
+
+	// session start/stop
+	h.set(regs.Enable, 0xff)
+	require.NoError(t, wb.Enable(true))
+	assert.Equal(t, regs.EnableValue, h.get(regs.Enable))
+
+	enabled, err := wb.Enabled()
+	require.NoError(t, err)
+	assert.True(t, enabled)
+
+	require.NoError(t, wb.Enable(false))
+	assert.Equal(t, regs.DisableValue, h.get(regs.Enable))
+
+	// enabled state is read from the device
+	h.set(regs.Enable, regs.EnableValue)
+	enabled, err = New(conn, regs).Enabled()
+	require.NoError(t, err)
+	assert.True(t, enabled)
+
+	// current setpoint in mA
+	assert.Error(t, wb.MaxCurrent(5))
+	require.NoError(t, wb.MaxCurrent(16))
+	assert.Equal(t, uint16(0), h.get(regs.Current))
+	assert.Equal(t, uint16(16000), h.get(regs.Current+1))
+
+	// meter
+	h.set(regs.Power, 0, 11000)
+	power, err := wb.CurrentPower()
+	require.NoError(t, err)
+	assert.Equal(t, 11000.0, power)
+
+	h.set(regs.Energy, 1, 0x86a0) // 100000 Wh
+	energy, err := wb.ChargedEnergy()
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, energy)
+
+	// charging time since vehicle connected
+	h.set(regs.Status, 0, 0)
+	_, err = wb.Status()
+	require.NoError(t, err)
+
+	d, err := wb.ChargingTime()
+	require.NoError(t, err)
+	assert.Zero(t, d)
+
+	h.set(regs.Status, 0, 4<<8)
+	_, err = wb.Status()
+	require.NoError(t, err)
+
+	clck.Add(time.Hour)
+	_, err = wb.Status()
+	require.NoError(t, err)
+
+	d, err = wb.ChargingTime()
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, d)
+}
+
+func TestChargerMissingRegisters(t *testing.T) {
+	_, err := NewFromConfig(map[string]interface{}{
+		"uri":    "localhost:502",
+		"status": 0x100,
+	})
+	assert.Error(t, err)
+
+	// register address 0 is valid
+	_, err = NewFromConfig(map[string]interface{}{
+		"uri":     "localhost:502",
+		"status":  0,
+		"enable":  0x200,
+		"current": 0x300,
+	})
+	if err != nil {
+		assert.NotContains(t, err.Error(), "missing")
+	}
+}
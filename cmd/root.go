@@ -23,6 +23,7 @@ import (
 	"github.com/evcc-io/evcc/util/sponsor"
 	"github.com/evcc-io/evcc/util/telemetry"
 	_ "github.com/joho/godotenv/autoload"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -211,6 +212,11 @@ func runRoot(cmd *cobra.Command, args []string) {
 		err = configureHEMS(conf.HEMS, site, httpd)
 	}
 
+	// aggregate remote sites
+	if err == nil && len(conf.Aggregate) > 0 {
+		configureAggregator(conf.Aggregate, conf.Interval, httpd)
+	}
+
 	// setup messaging
 	var pushChan chan push.Event
 	if err == nil {
@@ -256,6 +262,9 @@ func runRoot(cmd *cobra.Command, args []string) {
 
 		// set channels
 		site.DumpConfig()
+		if viper.GetBool("metrics") {
+			site.SetMetricsRegisterer(prometheus.DefaultRegisterer)
+		}
 		site.Prepare(valueChan, pushChan)
 
 		// show and check version, reduce api load during development
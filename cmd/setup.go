@@ -20,6 +20,7 @@ import (
 	"github.com/evcc-io/evcc/charger/eebus"
 	"github.com/evcc-io/evcc/cmd/shutdown"
 	"github.com/evcc-io/evcc/core"
+	"github.com/evcc-io/evcc/core/aggregator"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/hems"
 	"github.com/evcc-io/evcc/meter"
@@ -88,6 +89,7 @@ type globalConfig struct {
 	Influx       server.InfluxConfig
 	EEBus        map[string]interface{}
 	HEMS         config.Typed
+	Aggregate    []aggregateConfig
 	Messaging    messagingConfig
 	Meters       []config.Named
 	Chargers     []config.Named
@@ -118,6 +120,11 @@ type proxyConfig struct {
 	modbus.Settings `mapstructure:",squash"`
 }
 
+type aggregateConfig struct {
+	ID  string
+	URI string
+}
+
 type dbConfig struct {
 	Type string
 	Dsn  string
@@ -134,6 +141,7 @@ type tariffConfig struct {
 	FeedIn   config.Typed
 	Co2      config.Typed
 	Planner  config.Typed
+	Solar    config.Typed
 }
 
 type networkConfig struct {
@@ -553,6 +561,21 @@ func configureHEMS(conf config.Typed, site *core.Site, httpd *server.HTTPd) erro
 	return nil
 }
 
+// setup site aggregator
+func configureAggregator(conf []aggregateConfig, interval time.Duration, httpd *server.HTTPd) {
+	agg := aggregator.New()
+	for _, cc := range conf {
+		agg.AddSite(cc.ID, cc.URI)
+	}
+
+	httpd.RegisterAggregateHandler(agg)
+
+	go func() {
+		agg.Update()
+		agg.Run(interval)
+	}()
+}
+
 // setup MDNS
 func configureMDNS(conf networkConfig) error {
 	host := strings.TrimSuffix(conf.Host, ".local")
@@ -628,12 +651,13 @@ func configureTariffs(conf tariffConfig) (*tariff.Tariffs, error) {
 	}
 
 	var wg sync.WaitGroup
-	wg.Add(4)
+	wg.Add(5)
 
 	go configureTariff("grid", conf.Grid, &tariffs.Grid, &wg)
 	go configureTariff("feedin", conf.FeedIn, &tariffs.FeedIn, &wg)
 	go configureTariff("co2", conf.Co2, &tariffs.Co2, &wg)
 	go configureTariff("planner", conf.Planner, &tariffs.Planner, &wg)
+	go configureTariff("solar", conf.Solar, &tariffs.Solar, &wg)
 
 	wg.Wait()
 
@@ -682,6 +706,10 @@ func configureLoadpoints(conf globalConfig) (loadpoints []*core.Loadpoint, err e
 		return nil, errors.New("missing loadpoints")
 	}
 
+	// stop config watchers on shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	shutdown.Register(cancel)
+
 	for id, lpc := range conf.Loadpoints {
 		log := util.NewLoggerWithLoadpoint("lp-"+strconv.Itoa(id+1), id+1)
 		settings := &core.Settings{Key: "lp" + strconv.Itoa(id+1) + "."}
@@ -691,6 +719,13 @@ func configureLoadpoints(conf globalConfig) (loadpoints []*core.Loadpoint, err e
 			return nil, fmt.Errorf("failed configuring loadpoint: %w", err)
 		}
 
+		// apply non-breaking changes without restart
+		if cfgFile != "" {
+			if err := lp.WatchConfig(ctx, cfgFile); err != nil {
+				log.WARN.Printf("config watcher: %v", err)
+			}
+		}
+
 		loadpoints = append(loadpoints, lp)
 	}
 
@@ -0,0 +1,169 @@
+package aggregator
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+)
+
+// loadpointState is the part of a remote loadpoint's state used for aggregation
+type loadpointState struct {
+	Connected   bool    `json:"connected"`
+	ChargePower float64 `json:"chargePower"`
+	VehicleSoc  float64 `json:"vehicleSoc"`
+}
+
+// siteState is the part of a remote site's /api/state response used for aggregation
+type siteState struct {
+	Result struct {
+		SiteTitle  string           `json:"siteTitle"`
+		Loadpoints []loadpointState `json:"loadpoints"`
+	} `json:"result"`
+}
+
+type site struct {
+	id      string
+	uri     string
+	state   siteState
+	updated time.Time
+	stale   bool
+}
+
+// SiteMetrics are the aggregated metrics of a single remote site
+type SiteMetrics struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title,omitempty"`
+	ChargePower float64   `json:"chargePower"`
+	Sessions    int       `json:"sessions"`
+	VehicleSoc  *float64  `json:"vehicleSoc,omitempty"`
+	Updated     time.Time `json:"updated"`
+	Stale       bool      `json:"stale"`
+}
+
+// Metrics are the combined metrics of all remote sites
+type Metrics struct {
+	ChargePower float64       `json:"chargePower"`
+	Sessions    int           `json:"sessions"`
+	VehicleSoc  *float64      `json:"vehicleSoc,omitempty"`
+	Sites       []SiteMetrics `json:"sites"`
+}
+
+// SiteAggregator polls remote evcc instances and combines their charging metrics
+type SiteAggregator struct {
+	mu     sync.Mutex
+	log    *util.Logger
+	helper *request.Helper
+	sites  []*site
+}
+
+// New creates a site aggregator
+func New() *SiteAggregator {
+	log := util.NewLogger("aggregator")
+
+	return &SiteAggregator{
+		log:    log,
+		helper: request.NewHelper(log),
+	}
+}
+
+// AddSite adds a remote evcc instance by its base url
+func (a *SiteAggregator) AddSite(id, uri string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.sites = append(a.sites, &site{
+		id:    id,
+		uri:   strings.TrimSuffix(util.DefaultScheme(uri, "http"), "/"),
+		stale: true,
+	})
+}
+
+// Run polls all sites at the given interval
+func (a *SiteAggregator) Run(interval time.Duration) {
+	for range time.Tick(interval) {
+		a.Update()
+	}
+}
+
+// Update polls all sites once. Sites that cannot be reached keep their last known state and are marked stale.
+func (a *SiteAggregator) Update() {
+	a.mu.Lock()
+	sites := append([]*site(nil), a.sites...)
+	a.mu.Unlock()
+
+	for _, s := range sites {
+		var res siteState
+		err := a.helper.GetJSON(s.uri+"/api/state", &res)
+
+		a.mu.Lock()
+		if err == nil {
+			s.state = res
+			s.updated = time.Now()
+			s.stale = false
+		} else {
+			a.log.WARN.Printf("site %s: %v", s.id, err)
+			s.stale = true
+		}
+		a.mu.Unlock()
+	}
+}
+
+// Aggregate returns the combined metrics of all sites
+func (a *SiteAggregator) Aggregate() Metrics {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	res := Metrics{
+		Sites: make([]SiteMetrics, 0, len(a.sites)),
+	}
+
+	var socSum float64
+	var socCount int
+
+	for _, s := range a.sites {
+		sm := SiteMetrics{
+			ID:      s.id,
+			Title:   s.state.Result.SiteTitle,
+			Updated: s.updated,
+			Stale:   s.stale,
+		}
+
+		var siteSocSum float64
+		var siteSocCount int
+
+		for _, lp := range s.state.Result.Loadpoints {
+			sm.ChargePower += lp.ChargePower
+
+			if lp.Connected {
+				sm.Sessions++
+
+				if lp.VehicleSoc > 0 {
+					siteSocSum += lp.VehicleSoc
+					siteSocCount++
+				}
+			}
+		}
+
+		if siteSocCount > 0 {
+			soc := siteSocSum / float64(siteSocCount)
+			sm.VehicleSoc = &soc
+		}
+
+		res.ChargePower += sm.ChargePower
+		res.Sessions += sm.Sessions
+		socSum += siteSocSum
+		socCount += siteSocCount
+
+		res.Sites = append(res.Sites, sm)
+	}
+
+	if socCount > 0 {
+		soc := socSum / float64(socCount)
+		res.VehicleSoc = &soc
+	}
+
+	return res
+}
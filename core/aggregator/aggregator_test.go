@@ -0,0 +1,69 @@
+package aggregator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stateServer(state string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/state" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(state))
+	}))
+}
+
+func TestAggregate(t *testing.T) {
+	s1 := stateServer(`{"result":{"siteTitle":"Home","loadpoints":[
+		{"connected":true,"chargePower":11000,"vehicleSoc":40},
+		{"connected":false,"chargePower":0}
+	]}}`)
+	defer s1.Close()
+
+	s2 := stateServer(`{"result":{"siteTitle":"Office","loadpoints":[
+		{"connected":true,"chargePower":3700,"vehicleSoc":80}
+	]}}`)
+
+	agg := New()
+	agg.AddSite("home", s1.URL)
+	agg.AddSite("office", s2.URL+"/")
+
+	// not yet polled
+	res := agg.Aggregate()
+	require.Len(t, res.Sites, 2)
+	assert.True(t, res.Sites[0].Stale)
+	assert.Nil(t, res.VehicleSoc)
+
+	agg.Update()
+
+	res = agg.Aggregate()
+	assert.Equal(t, 14700.0, res.ChargePower)
+	assert.Equal(t, 2, res.Sessions)
+	require.NotNil(t, res.VehicleSoc)
+	assert.Equal(t, 60.0, *res.VehicleSoc)
+
+	assert.Equal(t, "Home", res.Sites[0].Title)
+	assert.Equal(t, 11000.0, res.Sites[0].ChargePower)
+	assert.Equal(t, 1, res.Sites[0].Sessions)
+	assert.False(t, res.Sites[0].Stale)
+	assert.False(t, res.Sites[1].Stale)
+
+	// unreachable site keeps last known state
+	updated := res.Sites[1].Updated
+	s2.Close()
+	agg.Update()
+
+	res = agg.Aggregate()
+	assert.Equal(t, 14700.0, res.ChargePower)
+	assert.False(t, res.Sites[0].Stale)
+	assert.True(t, res.Sites[1].Stale)
+	assert.Equal(t, 3700.0, res.Sites[1].ChargePower)
+	assert.Equal(t, updated, res.Sites[1].Updated)
+}
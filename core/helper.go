@@ -22,14 +22,6 @@ func bo() *backoff.ExponentialBackOff {
 	return bo
 }
 
-// powerToCurrent is a helper function to convert power to per-phase current
-func powerToCurrent(power float64, phases int) float64 {
-	if Voltage == 0 {
-		panic("Voltage is not set")
-	}
-	return power / (float64(phases) * Voltage)
-}
-
 // sitePower returns the available delta power that the charger might additionally consume
 // negative value: available power (grid export), positive value: grid import
 func sitePower(log *util.Logger, maxGrid, grid, battery, residual float64) float64 {
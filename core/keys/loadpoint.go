@@ -10,6 +10,7 @@ const (
 	MinSoc           = "minSoc"      // min soc
 	LimitSoc         = "limitSoc"    // limit soc
 	LimitEnergy      = "limitEnergy" // limit energy
+	SocTarget        = "socTarget"   // default soc target
 	EnableThreshold  = "enableThreshold"
 	DisableThreshold = "disableThreshold"
 
@@ -18,6 +19,7 @@ const (
 	PhasesActive     = "phasesActive"     // active phases as used by vehicle (1/2/3)
 
 	ChargerIcon           = "chargerIcon"           // charger icon for ui
+	ChargerCapabilities   = "chargerCapabilities"   // charger optional interfaces
 	ChargerFeature        = "chargerFeature"        // charger feature
 	ChargerPhysicalPhases = "chargerPhysicalPhases" // charger phases
 	ChargerPhases1p3p     = "chargerPhases1p3p"     // phase switcher (1p3p chargers)
@@ -27,6 +29,93 @@ const (
 	Connected = "connected" // connected
 	Charging  = "charging"  // charging
 
+	// toggle limit
+	ToggleCount       = "toggleCount"       // charger enable/disable transitions within the last hour
+	ToggleLimitActive = "toggleLimitActive" // charger disable held back due to toggle limit
+
+	PvCapacityEstimateW = "pvCapacityEstimateW" // estimated pv capacity from rolling surplus peak
+	PvPeaks             = "pvPeaks"             // daily surplus peaks of the pv capacity estimate
+
+	// contactor wear
+	ContactorOps         = "contactorOps"         // charger enable/disable transitions since installation
+	ContactorWearWarning = "contactorWearWarning" // contactor operations reached maintenance interval
+
+	DetectedCurrentStep = "detectedCurrentStep" // discovered charger current step size
+
+	// session state
+	ChargeRaterState = "chargeRaterState" // persisted charged energy
+	ChargeTimerState = "chargeTimerState" // persisted charging duration
+
+	// external current control
+	ExternalCurrent       = "externalCurrent"       // externally commanded charge current
+	ExternalCurrentActive = "externalCurrentActive" // external charge current is valid and applied
+	ExternalCurrentAge    = "externalCurrentAge"    // time since last external charge current update
+
+	VoltageBoostActive = "voltageBoostActive" // higher supply voltage requested
+
+	CurrentCorrectionFactor = "currentCorrectionFactor" // ratio of measured to commanded charge current
+
+	// temperature derating
+	ChargerTemperature = "chargerTemperature" // charger temperature
+	DeratingActive     = "deratingActive"     // max current reduced due to temperature
+	DeratedMaxCurrent  = "deratedMaxCurrent"  // temperature derated max current
+
+	// remote start
+	RemoteSessionActive = "remoteSessionActive" // remotely started session active
+	RemoteSessionToken  = "remoteSessionToken"  // masked remote session token
+
+	// force charge
+	ForceCharge = "forceCharge" // charging forced until vehicle disconnects
+
+	// flexible schedule
+	ActiveFlexSlot = "activeFlexSlot" // active flexible charging window
+
+	HolidayModeActive = "holidayModeActive" // charging disabled on configured holiday
+
+	OffPeakActive = "offPeakActive" // offpeak mode charging immediately within off-peak window
+
+	MinSocActive = "minSocActive" // pv mode charging at min current below pv min soc
+
+	// grid export limit
+	GridExportLimit = "gridExportLimit" // grid export not used for charging
+	GridExport      = "gridExport"      // current grid export
+
+	PhaseDowngradeActive = "phaseDowngradeActive" // switched to 1p for continued pv charging
+	SocPhaseSwitchActive = "socPhaseSwitchActive" // switched to 1p for high vehicle soc
+
+	PhaseSwitchCooldownRemaining = "phaseSwitchCooldownRemaining" // remaining duration until phases may be switched again
+
+	PowerInferredPhases = "powerInferredPhases" // active phases inferred from charge power
+
+	// site meter offline fallback
+	MeterOfflineFallbackActive = "meterOfflineFallbackActive" // fallback current applied while site meter is unavailable
+
+	// day/night rate
+	NightRateActive = "nightRateActive" // night rate min current floor active
+
+	// pv disable extension
+	DisableTimerExtended = "disableTimerExtended" // pv disable delay extended at low vehicle soc
+
+	// green charging
+	GreenChargeReason = "greenChargeReason" // active green charging rule
+	CarbonIntensity   = "carbonIntensity"   // current grid co2 intensity in g/kWh
+
+	// scheduled charging
+	DepartureTime    = "departureTime"    // scheduled charging departure time
+	ScheduledCurrent = "scheduledCurrent" // current required to reach limit soc by departure time
+	CheapSlotActive  = "cheapSlotActive"  // cheap mode charging window active
+
+	// cost alert
+	CostAlertFired = "costAlertFired" // session cost exceeded alert threshold
+
+	// min connect energy
+	MinConnectActive    = "minConnectActive"    // top-up charge after connecting active
+	MinConnectRemaining = "minConnectRemaining" // remaining top-up energy in kWh
+
+	// max current detection
+	MaxCurrentDetectActive = "maxCurrentDetectActive" // ramping up current to detect charger max current
+	DetectedMaxCurrent     = "detectedMaxCurrent"     // detected charger max current
+
 	// smart charging
 	SmartCostActive = "smartCostActive" // smart cost active
 	SmartCostLimit  = "smartCostLimit"  // smart cost limit
@@ -48,10 +137,29 @@ const (
 	ChargeDuration    = "chargeDuration"    // charge duration
 	ChargeTotalImport = "chargeTotalImport" // charge meter total import
 
+	// phase imbalance
+	PhaseImbalance       = "phaseImbalance"       // max phase current deviation from mean
+	PhaseImbalanceActive = "phaseImbalanceActive" // phase current deviation exceeds limit
+
+	// statistics
+	LifetimeSolarEnergy = "lifetimeSolarEnergy" // lifetime solar energy delivered to vehicles
+	PvFairnessRatio     = "pvFairnessRatio"     // monthly received vs priority-weighted entitled pv energy
+
 	// session
 	ConnectedDuration       = "connectedDuration"       // connected duration
 	ChargeRemainingDuration = "chargeRemainingDuration" // charge remaining duration
 	ChargeRemainingEnergy   = "chargeRemainingEnergy"   // charge remaining energy
+	LimitEnergyRemaining    = "limitEnergyRemaining"    // session energy remaining until limit
+
+	// connect duration statistics
+	AvgConnectedDuration = "avgConnectedDurationMin" // average session connected duration in minutes
+	AvgChargingDuration  = "avgChargingDurationMin"  // average session charging duration in minutes
+	AvgIdleDuration      = "avgIdleDurationMin"      // average session idle duration in minutes
+	ChargingUtilisation  = "chargingUtilisationPct"  // charging share of connected duration in %
+
+	// session duration limit
+	MaxSessionDurationActive = "maxSessionDurationActive" // session stopped due to max session duration
+	SessionTimeRemaining     = "sessionTimeRemaining"     // remaining charge duration until max session duration
 
 	// plan
 	PlanTime           = "planTime"           // charge plan finish time goal
@@ -61,6 +169,13 @@ const (
 	PlanProjectedStart = "planProjectedStart" // charge plan start time (earliest slot)
 	PlanOverrun        = "planOverrun"        // charge plan goal not reachable in time
 
+	// one-time schedule
+	OneTimeScheduleActive = "oneTimeScheduleActive" // one-time plan overrides vehicle plan for current session
+	OneTimeDeparture      = "oneTimeDeparture"      // one-time plan departure time
+
+	// watchdog
+	Watchdog = "watchdog" // watchdog error
+
 	// remote control
 	RemoteDisabled       = "remoteDisabled"       // remote disabled
 	RemoteDisabledSource = "remoteDisabledSource" // remote disabled source
@@ -73,5 +188,6 @@ const (
 	VehicleRange           = "vehicleRange"           // vehicle range
 	VehicleSoc             = "vehicleSoc"             // vehicle soc
 	VehicleTargetSoc       = "vehicleTargetSoc"       // vehicle api soc limit
+	VehicleCurrentLimit    = "vehicleCurrentLimit"    // vehicle api charge current limit
 	VehicleClimaterActive  = "vehicleClimaterActive"  // vehicle climater active
 )
@@ -4,6 +4,7 @@ const (
 	Aux                   = "aux"
 	AuxPower              = "auxPower"
 	Currency              = "currency"
+	EnergyFlow            = "energyFlow"
 	GreenShareHome        = "greenShareHome"
 	GreenShareLoadpoints  = "greenShareLoadpoints"
 	GridConfigured        = "gridConfigured"
@@ -12,6 +13,7 @@ const (
 	GridPower             = "gridPower"
 	GridPowers            = "gridPowers"
 	HomePower             = "homePower"
+	HousePower            = "housePower"
 	PrioritySoc           = "prioritySoc"
 	Pv                    = "pv"
 	PvConfigured          = "pvConfigured"
@@ -49,4 +51,11 @@ const (
 	BatteryMode       = "batteryMode"
 	BatteryPower      = "batteryPower"
 	BatterySoc        = "batterySoc"
+
+	// loadpoint aggregates
+	TotalChargePower  = "totalChargePower"
+	ConnectedVehicles = "connectedVehicles"
+
+	// pv forecast
+	ForecastConfidence = "forecastConfidence"
 )
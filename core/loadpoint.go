@@ -1,12 +1,16 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -17,6 +21,7 @@ import (
 	"github.com/evcc-io/evcc/core/coordinator"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/metrics"
 	"github.com/evcc-io/evcc/core/planner"
 	"github.com/evcc-io/evcc/core/session"
 	"github.com/evcc-io/evcc/core/soc"
@@ -27,6 +32,7 @@ import (
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/config"
 	"github.com/evcc-io/evcc/util/telemetry"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 const (
@@ -38,6 +44,11 @@ const (
 	evVehicleDisconnect   = "disconnect" // vehicle disconnected
 	evVehicleSoc          = "soc"        // vehicle soc progress
 	evVehicleUnidentified = "guest"      // vehicle unidentified
+	evAnnualSummary       = "annual"     // annual summary
+	evCostAlert           = "costalert"  // session cost exceeds alert threshold
+	evChargerOffline      = "offline"    // charger reconnect failed
+	evContactorWear       = "contactor"  // contactor operations reached maintenance interval
+	evSessionSummary      = "summary"    // charging session summary after disconnect
 
 	pvTimer   = "pv"
 	pvEnable  = "enable"
@@ -49,11 +60,13 @@ const (
 
 	timerInactive = "inactive"
 
-	minActiveCurrent = 1.0 // minimum current at which a phase is treated as active
+	minActiveCurrent = 1.0 // default minimum current at which a phase is treated as active
 	minActiveVoltage = 207 // minimum voltage at which a phase is treated as active
+	maxImbalance     = 4.6 // default maximum phase current deviation from mean
 
-	chargerSwitchDuration = 60 * time.Second // allow out of sync during this timespan
-	phaseSwitchDuration   = 60 * time.Second // allow out of sync and do not measure phases during this timespan
+	chargerSwitchDuration      = 60 * time.Second // allow out of sync during this timespan
+	rapidChargerSwitchDuration = 10 * time.Second // allow out of sync during this timespan for chargers with rapid current feature
+	phaseSwitchDuration        = 60 * time.Second // allow out of sync and do not measure phases during this timespan
 )
 
 // elapsed is the time an expired timer will be set to
@@ -61,8 +74,9 @@ var elapsed = time.Unix(0, 1)
 
 // PollConfig defines the vehicle polling mode and interval
 type PollConfig struct {
-	Mode     string        `mapstructure:"mode"`     // polling mode charging (default), connected, always
-	Interval time.Duration `mapstructure:"interval"` // interval when not charging
+	Mode             string        `mapstructure:"mode"`             // polling mode charging (default), connected, always
+	Interval         time.Duration `mapstructure:"interval"`         // interval when not charging
+	ChargingInterval time.Duration `mapstructure:"chargingInterval"` // interval when charging, soc is estimated in between, 0 = every update
 }
 
 // SocConfig defines soc settings, estimation and update behavior
@@ -82,8 +96,57 @@ const (
 
 // ThresholdConfig defines enable/disable hysteresis parameters
 type ThresholdConfig struct {
-	Delay     time.Duration
-	Threshold float64
+	Delay            time.Duration
+	Threshold        float64 // W
+	ThresholdPercent float64 // % of estimated pv capacity, replaces threshold once pv capacity is estimated
+}
+
+// DeratingConfig defines temperature dependent max current reduction
+type DeratingConfig struct {
+	Enable    bool    `mapstructure:"enable"`
+	StartTemp float64 `mapstructure:"startTemp"` // °C, start of reduction
+	EndTemp   float64 `mapstructure:"endTemp"`   // °C, current reduced to zero
+}
+
+// DisableExtensionConfig defines the pv disable delay extension at low vehicle soc
+type DisableExtensionConfig struct {
+	Enable   bool    `mapstructure:"enable"`
+	BelowSoc float64 `mapstructure:"belowSoc"` // %, extend disable delay below this soc
+	Factor   float64 `mapstructure:"factor"`   // disable delay multiplier
+}
+
+// ProfileEntry defines current limits for a daily time window
+type ProfileEntry struct {
+	Start      string `mapstructure:"start"`      // HH:MM
+	End        string `mapstructure:"end"`        // HH:MM
+	MinCurrent int64  `mapstructure:"minCurrent"` // 0 = loadpoint min current
+	MaxCurrent int64  `mapstructure:"maxCurrent"` // 0 = loadpoint max current
+}
+
+// FlexSlot defines a daily charging window and its soc target
+type FlexSlot struct {
+	Start     string  `mapstructure:"start"` // HH:MM
+	End       string  `mapstructure:"end"`   // HH:MM
+	TargetSoc float64 `mapstructure:"targetSoc"`
+}
+
+// CommandRetryConfig defines the retry backoff for failed vehicle commands
+type CommandRetryConfig struct {
+	Attempts     int           `mapstructure:"attempts"`     // total attempts including the first one
+	InitialDelay time.Duration `mapstructure:"initialDelay"` // delay before the first retry, doubled per retry
+	MaxDelay     time.Duration `mapstructure:"maxDelay"`     // maximum retry delay
+}
+
+// ReadRetryConfig defines the retry backoff for reading charger status and charge power
+type ReadRetryConfig struct {
+	Count int           `mapstructure:"count"` // retries after the first failed attempt, 0 = default
+	Delay time.Duration `mapstructure:"delay"` // delay before the first retry, doubled per retry
+}
+
+// HistoryConfig configures the charge history log
+type HistoryConfig struct {
+	DataDir string `mapstructure:"dataDir"` // directory of the charge history log, empty = disabled
+	MaxSize int64  `mapstructure:"maxSize"` // rotate charge history log at this size in bytes, 0 = default
 }
 
 // Task is the task type
@@ -99,6 +162,14 @@ type Loadpoint struct {
 	lpChan   chan<- *Loadpoint // update requests
 	log      *util.Logger
 
+	metricsRegisterer prometheus.Registerer // optional metrics registry
+	metrics           *metrics.Collector    // metrics for published values
+
+	watchdogC       chan struct{} // update notifications for the watchdog
+	watchdogTripped atomic.Bool   // watchdog timeout pending, set by the watchdog
+	watchdogError   bool          // watchdog error published
+	chargerMu       sync.Mutex    // serialises charger enable commands of update loop and watchdog
+
 	// exposed public configuration
 	sync.RWMutex // guard status
 
@@ -112,6 +183,94 @@ type Loadpoint struct {
 	MeterRef        string `mapstructure:"meter"`    // Charge meter reference
 	Soc             SocConfig
 	Enable, Disable ThresholdConfig
+	SocRamp         bool `mapstructure:"socRamp"`           // Taper charge current towards limit soc
+	MaxToggles      int  `mapstructure:"maxTogglesPerHour"` // PV mode: maximum charger enable/disable transitions per hour
+
+	PVCapacityEstimate bool `mapstructure:"pvCapacityEstimate"` // PV mode: estimate pv capacity for percent thresholds
+
+	ContactorWarnAt int `mapstructure:"contactorWarnAt"` // Notify every n charger enable/disable transitions, 0 = disabled
+
+	MinPVSocScaling bool `mapstructure:"minPVSocScaling"` // MinPV mode: reduce grid share of min current towards limit soc
+
+	DiscoverCurrentGranularity bool `mapstructure:"discoverCurrentGranularity"` // Detect charger current step size on startup
+
+	SocTarget int `mapstructure:"socTarget"` // Stop charging at this vehicle soc unless overridden by session or vehicle limit, 0 = disabled
+
+	PVMinSoc int `mapstructure:"pvMinSoc"` // PV mode: charge at least min current below this vehicle soc, 0 = disabled
+
+	SmoothingWindow int `mapstructure:"smoothingWindow"` // PV mode: average site power over this number of samples, <= 1 = disabled
+
+	VehicleRefs []string `mapstructure:"vehicles"` // Vehicles identifiable at this loadpoint, first is used if identification fails
+
+	ChargeProfile []ProfileEntry `mapstructure:"chargeProfile"` // Min and max current by time of day
+
+	GridExportLimit float64 `mapstructure:"gridExportLimit"` // PV mode: charge only surplus exceeding this grid export in W, 0 = disabled
+
+	DayRateStart    time.Duration `mapstructure:"dayRateStart"`    // Day rate start as time of day
+	NightRateStart  time.Duration `mapstructure:"nightRateStart"`  // Night rate start as time of day
+	NightMinCurrent int64         `mapstructure:"nightMinCurrent"` // MinPV mode: min current during night rate, 0 = disabled
+
+	ExternalCurrentControl bool          `mapstructure:"externalCurrentControl"` // Charge current is commanded by external controller
+	ExternalTimeout        time.Duration `mapstructure:"externalTimeout"`        // Disable charging if external current is not updated
+
+	OfflineFallbackCurrent int64         `mapstructure:"offlineFallback"`        // Charge current while site meter is unavailable, 0 = disable charging
+	OfflineFallbackTimeout time.Duration `mapstructure:"offlineFallbackTimeout"` // Site meter outage before fallback applies, 0 = disabled
+
+	WatchdogTimeout time.Duration `mapstructure:"watchdogTimeout"` // Disable charging if loadpoint is not updated, 0 = disabled
+
+	SocWebhookURL string `mapstructure:"socWebhook"` // Post vehicle soc to this url on every soc read
+
+	CurrentFeedback    bool          `mapstructure:"currentFeedback"`    // Correct charge current for systematic charger error
+	MaxSessionDuration time.Duration `mapstructure:"maxSessionDuration"` // Stop charging after session charge duration, 0 = unlimited
+
+	Derating DeratingConfig `mapstructure:"derating"` // Reduce max current at high charger temperature
+
+	DisableExtension DisableExtensionConfig `mapstructure:"disableExtension"` // PV mode: extend disable delay at low vehicle soc
+
+	AllowedTokens []string `mapstructure:"allowedTokens"` // Tokens authorizing remote start
+
+	Voltage float64 `mapstructure:"voltage"` // Nominal phase voltage in V, 0 = site voltage
+
+	MeterOffset float64 `mapstructure:"meterOffset"` // Charge meter power correction in W
+	MeterScale  float64 `mapstructure:"meterScale"`  // Charge meter power correction factor
+
+	MinActiveCurrent float64 `mapstructure:"minActiveCurrent"` // Minimum phase current treated as active, 0 = default
+
+	MaxImbalance float64 `mapstructure:"maxImbalance"` // Maximum phase current deviation from mean before warning, 0 = default
+
+	FlexSchedule []FlexSlot `mapstructure:"flexSchedule"` // Cost optimized charging within daily windows only
+
+	HolidayDates []string `mapstructure:"holidays"` // No charging on these dates, YYYY-MM-DD or recurring MM-DD
+
+	OffPeakStart string `mapstructure:"offPeakStart"` // OffPeak mode: daily off-peak window start, HH:MM
+	OffPeakEnd   string `mapstructure:"offPeakEnd"`   // OffPeak mode: daily off-peak window end, HH:MM
+
+	PhaseAutoDowngrade bool          `mapstructure:"phaseAutoDowngrade"` // PV mode: switch to 1p without delay if 3p min current is not available
+	MinChargeInterval  time.Duration `mapstructure:"minChargeInterval"`  // PV mode: minimum charging duration before disabling
+	SocPhaseThreshold  float64       `mapstructure:"socPhaseThreshold"`  // PV mode: charge 1p above this vehicle soc, 0 = disabled
+
+	AutoPhaseDetectFromPower bool `mapstructure:"autoPhaseDetectFromPower"` // Infer active phases from charge power if charger has no phase currents
+
+	PhaseSwitchCooldownDuration time.Duration `mapstructure:"phaseSwitchCooldown"` // PV mode: minimum time between phase switches
+
+	CommandRetry CommandRetryConfig `mapstructure:"commandRetry"` // Retry failed vehicle commands
+
+	ReadRetry ReadRetryConfig `mapstructure:"readRetry"` // Retry failed charger status and charge meter reads
+
+	GreenThreshold float64 `mapstructure:"greenThreshold"` // Green mode: charge from grid below this co2 intensity in g/kWh, 0 = disabled
+
+	EnableFrequency  float64 `mapstructure:"enableFrequency"`  // GridFriendly mode: enable charging at or above this grid frequency in Hz, 0 = default
+	DisableFrequency float64 `mapstructure:"disableFrequency"` // GridFriendly mode: disable charging at or below this grid frequency in Hz, 0 = default
+
+	CostAlertThreshold float64 `mapstructure:"costAlertThreshold"` // Notify when session cost exceeds threshold in Currency
+
+	CurrentMismatchLog bool `mapstructure:"currentMismatchLog"` // Log commanded vs measured current deviations to mismatch.log in the history data dir
+
+	History HistoryConfig `mapstructure:"history"` // Log finished sessions as JSON Lines
+
+	MinConnectEnergy float64 `mapstructure:"minConnectEnergy"` // Charge energy in kWh at min current after connecting, 0 = disabled
+
+	AutoMaxCurrentDetect bool `mapstructure:"autoMaxCurrentDetect"` // Detect charger max current by ramping up on first connect
 
 	// TODO deprecated
 	GuardDuration_    time.Duration `mapstructure:"guardduration"` // charger enable/disable minimum holding time
@@ -132,8 +291,11 @@ type Loadpoint struct {
 	measuredPhases      int       // Charger physically measured phases
 	chargeCurrent       float64   // Charger current limit
 	socUpdated          time.Time // Soc updated timestamp (poll: connected)
+	virtualSocUpdated   time.Time // Virtual vehicle soc estimation timestamp
+	socEstimated        bool      // Soc estimated from charged energy since last charging poll
 	vehicleDetect       time.Time // Vehicle connected timestamp
 	chargerSwitched     time.Time // Charger enabled/disabled timestamp
+	chargeStartedAt     time.Time // Charging started timestamp
 	phasesSwitched      time.Time // Phase switch timestamp
 	vehicleDetectTicker *clock.Ticker
 	vehicleIdentifier   string
@@ -149,6 +311,11 @@ type Loadpoint struct {
 	coordinator    coordinator.API
 	socEstimator   *soc.Estimator
 
+	vehicles []api.Vehicle // Vehicles identifiable at this loadpoint, empty = all
+
+	gridFrequencyMeter api.FrequencyMeter // Site grid frequency meter
+	co2Tariff          api.Tariff         // Site co2 tariff for green charging
+
 	// charge planning
 	planner     *planner.Planner
 	planTime    time.Time // time goal
@@ -156,6 +323,10 @@ type Loadpoint struct {
 	planSlotEnd time.Time // current plan slot end time
 	planActive  bool      // charge plan exists and has a currently active slot
 
+	oneTimeDeparture time.Time // one-time schedule departure for a single session
+	departureTime    time.Time // scheduled charging departure time
+	oneTimeSoc       int       // one-time schedule soc target
+
 	// cached state
 	status         api.ChargeStatus       // Charger status
 	remoteDemand   loadpoint.RemoteDemand // External status demand
@@ -164,16 +335,115 @@ type Loadpoint struct {
 	connectedTime  time.Time              // Time when vehicle was connected
 	pvTimer        time.Time              // PV enabled/disable timer
 	phaseTimer     time.Time              // 1p3p switch timer
+	phaseDowngrade bool                   // 1p switch to avoid stopping
+	socPhaseSwitch bool                   // 1p switch for high vehicle soc
+	phaseImbalance bool                   // Phase current imbalance exceeds limit
+	toggles        []time.Time            // Charger enable/disable timestamps within the last hour
 	wakeUpTimer    *Timer                 // Vehicle wake-up timeout
 
+	contactorOps         int  // Charger enable/disable transitions since installation
+	contactorWearWarning bool // Contactor operations reached maintenance interval
+
+	detectedCurrentStep float64 // Charger current step size, 0 = not detected
+
+	pvPeaks            []pvPeak // Daily surplus peaks for pv capacity estimation
+	pvCapacityEstimate float64  // Estimated pv capacity in W
+
+	sitePowers []float64 // Site power samples for smoothing
+
 	// charge progress
 	vehicleSoc              float64        // Vehicle Soc
 	chargeDuration          time.Duration  // Charge duration
 	sessionEnergy           *EnergyMetrics // Stats for charged energy by session
 	chargeRemainingDuration time.Duration  // Remaining charge duration
 	chargeRemainingEnergy   float64        // Remaining charge energy in Wh
+	limitEnergyRemaining    float64        // Published remaining session energy until limit in kWh
 	progress                *Progress      // Step-wise progress indicator
 
+	// lifetime statistics
+	lifetimeSolarEnergy float64 // Solar energy delivered to vehicles since installation in kWh
+	annualSummaryYear   int     // Year of the last annual summary
+
+	// external current control
+	externalCurrent        int64     // Externally commanded charge current
+	externalCurrentUpdated time.Time // External charge current timestamp
+
+	// voltage boost
+	voltageBoostActive bool // Higher supply voltage requested
+
+	// current feedback
+	commandedCurrent float64         // Current limit sent to the charger
+	feedbackCommand  float64         // Commanded current at last feedback update
+	feedbackSamples  []currentSample // Commanded and measured current samples
+	correctionFactor float64         // Ratio of measured to commanded current
+
+	// temperature derating
+	chargerTemperature float64 // Charger temperature in °C
+
+	// remote start
+	remoteSession string // Token of remotely started session
+
+	// force charge
+	forceCharge bool // Charge immediately regardless of mode until vehicle disconnects
+
+	// load management
+	allocatedCurrent float64 // Site fuse current allocation per phase
+	currentAllocated bool    // Site fuse current allocation active
+	allocatedPower   float64 // Site grid power allocation in W
+	powerAllocated   bool    // Site grid power allocation active
+
+	// vehicle command retry
+	commandRetries map[string]retryState // Pending retries by command
+
+	// cost alert
+	costAlertFired bool // Session cost alert sent
+
+	// current mismatch log
+	mismatchLog    io.Writer // Current mismatch log destination, nil = disabled
+	mismatchActive bool      // Current mismatch ongoing
+	mismatchLogged time.Time // Last current mismatch log entry
+
+	// charge history log
+	historyLog io.Writer // Charge history log destination, nil = disabled
+
+	// charger reconnect
+	chargerFailures    int  // Consecutive charger status errors
+	chargerUnreachable bool // Charger reconnect failed
+
+	// min connect energy
+	minConnectActive bool // Charging min connect energy
+
+	// max current detection
+	detectActive       bool      // Ramping up current to detect max current
+	detectCurrent      float64   // Current detection step
+	detectStable       float64   // Last step with stable charging
+	detectStepped      time.Time // Detection step started
+	detectCharging     bool      // Charging since start of current detection step
+	detectedMaxCurrent float64   // Detected charger max current, 0 = unknown
+	detectedVehicle    string    // Vehicle the max current was detected with
+
+	// site meter offline fallback
+	offlineFallbackActive bool // Offline fallback current applied
+
+	// phase inference from charge power
+	inferredPhases      int // Phases inferred from recent charge power
+	inferredPhasesCount int // Consecutive consistent phase inferences
+
+	// vehicle current limit
+	vehicleCurrentLimit int64 // Charge current limit configured in the vehicle, 0 = unknown
+
+	// vehicle data export
+	socHistory    []socSample   // Vehicle soc readings
+	chargingCurve []curveSample // Charge power by vehicle soc
+
+	// event history
+	eventHistory []loadpoint.EventRecord // Recent loadpoint events
+
+	// pv fairness
+	pvEnergyReceived float64   // Pv energy charged this month in Wh
+	pvEnergyEntitled float64   // Priority-weighted share of pv surplus this month in Wh
+	pvFairnessMonth  time.Time // Start of fairness accounting month
+
 	// session log
 	db      *session.DB
 	session *session.Session
@@ -202,6 +472,89 @@ func NewLoadpointFromConfig(log *util.Logger, settings *Settings, other map[stri
 		lp.Soc.Poll.Mode = pollCharging
 	}
 
+	if lp.MeterScale <= 0 {
+		return nil, fmt.Errorf("invalid meter scale: %v", lp.MeterScale)
+	}
+
+	if lp.ReadRetry.Count < 0 || lp.ReadRetry.Delay < 0 {
+		return nil, fmt.Errorf("invalid read retry: %d/%v", lp.ReadRetry.Count, lp.ReadRetry.Delay)
+	}
+
+	if lp.GreenThreshold < 0 {
+		return nil, fmt.Errorf("invalid green threshold: %.4gg/kWh", lp.GreenThreshold)
+	}
+
+	if enable, disable := lp.gridFriendlyFrequencies(); enable <= disable {
+		return nil, fmt.Errorf("enable frequency %.3gHz must be above disable frequency %.3gHz", enable, disable)
+	}
+
+	if lp.Voltage != 0 && (lp.Voltage < 100 || lp.Voltage > 480) {
+		return nil, fmt.Errorf("invalid voltage: %.4gV", lp.Voltage)
+	}
+
+	if lp.MaxImbalance < 0 {
+		return nil, fmt.Errorf("invalid max imbalance: %.3gA", lp.MaxImbalance)
+	}
+
+	if lp.MinActiveCurrent > 6 {
+		lp.log.WARN.Printf("min active current %.3gA exceeds 6A and will likely prevent phase detection", lp.MinActiveCurrent)
+	}
+
+	if lp.SocTarget < 0 || lp.SocTarget > 100 {
+		return nil, fmt.Errorf("invalid soc target: %d", lp.SocTarget)
+	}
+
+	if lp.GridExportLimit < 0 {
+		return nil, fmt.Errorf("invalid grid export limit: %.0fW", lp.GridExportLimit)
+	}
+
+	if lp.PVMinSoc < 0 || lp.PVMinSoc > 100 {
+		return nil, fmt.Errorf("invalid pv min soc: %d", lp.PVMinSoc)
+	}
+
+	for _, date := range lp.HolidayDates {
+		if err := parseHoliday(date); err != nil {
+			return nil, err
+		}
+	}
+
+	if lp.History.MaxSize < 0 {
+		return nil, fmt.Errorf("invalid history max size: %d", lp.History.MaxSize)
+	}
+
+	if lp.History.DataDir != "" {
+		lp.historyLog = &rotatingFile{path: filepath.Join(lp.History.DataDir, historyLogFile(settings)), maxSize: lp.historyMaxSize()}
+	}
+
+	if lp.CurrentMismatchLog {
+		if lp.History.DataDir == "" {
+			return nil, errors.New("current mismatch log requires history data dir")
+		}
+		lp.mismatchLog = sharedMismatchLog(filepath.Join(lp.History.DataDir, mismatchLogFile))
+	}
+
+	if lp.OffPeakStart != "" || lp.OffPeakEnd != "" {
+		if _, _, err := dailyWindow(time.Now(), lp.OffPeakStart, lp.OffPeakEnd); err != nil {
+			return nil, fmt.Errorf("off-peak window: %w", err)
+		}
+	}
+
+	for _, slot := range lp.FlexSchedule {
+		if _, _, err := slot.window(time.Now()); err != nil {
+			return nil, fmt.Errorf("flex schedule: %w", err)
+		}
+	}
+
+	for _, entry := range lp.ChargeProfile {
+		if err := entry.validate(); err != nil {
+			return nil, fmt.Errorf("charge profile: %w", err)
+		}
+	}
+
+	if lp.Derating.Enable && lp.Derating.EndTemp <= lp.Derating.StartTemp {
+		return nil, errors.New("derating end temperature must be above start temperature")
+	}
+
 	if lp.MeterRef != "" {
 		dev, err := config.Meters().ByName(lp.MeterRef)
 		if err != nil {
@@ -219,6 +572,18 @@ func NewLoadpointFromConfig(log *util.Logger, settings *Settings, other map[stri
 		lp.defaultVehicle = dev.Instance()
 	}
 
+	if lp.VehicleRef != "" && len(lp.VehicleRefs) > 0 {
+		return nil, errors.New("vehicle and vehicles are mutually exclusive")
+	}
+
+	for _, ref := range lp.VehicleRefs {
+		dev, err := config.Vehicles().ByName(ref)
+		if err != nil {
+			return nil, err
+		}
+		lp.vehicles = append(lp.vehicles, dev.Instance())
+	}
+
 	if lp.ChargerRef == "" {
 		return nil, errors.New("missing charger")
 	}
@@ -273,7 +638,7 @@ func NewLoadpointFromConfig(log *util.Logger, settings *Settings, other map[stri
 // NewLoadpoint creates a Loadpoint with sane defaults
 func NewLoadpoint(log *util.Logger, settings *Settings) *Loadpoint {
 	clock := clock.New()
-	bus := evbus.New()
+	bus := util.NewInstrumentedBus()
 
 	lp := &Loadpoint{
 		log:        log,      // logger
@@ -292,15 +657,28 @@ func NewLoadpoint(log *util.Logger, settings *Settings) *Loadpoint {
 		},
 		Enable:        ThresholdConfig{Delay: time.Minute, Threshold: 0},     // t, W
 		Disable:       ThresholdConfig{Delay: 3 * time.Minute, Threshold: 0}, // t, W
+		Derating:      DeratingConfig{StartTemp: 35, EndTemp: 55},            // °C
+		MeterScale:    1,
 		sessionEnergy: NewEnergyMetrics(),
 		progress:      NewProgress(0, 10),     // soc progress indicator
 		coordinator:   coordinator.NewDummy(), // dummy vehicle coordinator
 		tasks:         util.NewQueue[Task](),  // task queue
+
+		PhaseSwitchCooldownDuration: 10 * time.Minute,
+		ContactorWarnAt:             5000,
 	}
 
 	return lp
 }
 
+// stateStore returns the settings as store for session state or nil if settings are not available
+func (lp *Loadpoint) stateStore() wrapper.StateStore {
+	if lp.settings == nil {
+		return nil
+	}
+	return lp.settings
+}
+
 // restoreSettings restores loadpoint settings
 func (lp *Loadpoint) restoreSettings() {
 	if testing.Testing() {
@@ -328,6 +706,25 @@ func (lp *Loadpoint) restoreSettings() {
 	if v, err := lp.settings.Float(keys.SmartCostLimit); err == nil {
 		lp.SetSmartCostLimit(v)
 	}
+	if v, err := lp.settings.Float(keys.LifetimeSolarEnergy); err == nil && v > 0 {
+		lp.lifetimeSolarEnergy = v
+	}
+	if v, err := lp.settings.Int(keys.ContactorOps); err == nil && v > 0 {
+		lp.contactorOps = int(v)
+		lp.contactorWearWarning = lp.ContactorWarnAt > 0 && lp.contactorOps >= lp.ContactorWarnAt
+	}
+	if lp.PVCapacityEstimate {
+		if err := lp.settings.Load(keys.PvPeaks, &lp.pvPeaks); err == nil {
+			lp.pvCapacityEstimate = maxPvPeak(lp.pvPeaks)
+		}
+	}
+	if v, err := lp.settings.Float(lp.detectedMaxCurrentKey()); err == nil && v > 0 && lp.AutoMaxCurrentDetect {
+		lp.detectedMaxCurrent = v
+		lp.detectedVehicle, _ = lp.settings.String(lp.detectedMaxCurrentKey() + ".vehicle")
+	}
+	if v, err := lp.settings.Time(keys.DepartureTime); err == nil && v.After(lp.clock.Now()) {
+		lp.departureTime = v
+	}
 	t, err1 := lp.settings.Time(keys.PlanTime)
 	v, err2 := lp.settings.Float(keys.PlanEnergy)
 	if err1 == nil && err2 == nil {
@@ -353,6 +750,8 @@ func (lp *Loadpoint) configureChargerType(charger api.Charger) {
 
 		if mt, ok := charger.(api.Meter); ok {
 			lp.chargeMeter = mt
+		} else if pc, ok := charger.(api.PhaseCurrents); ok {
+			lp.chargeMeter = wrapper.NewThreePhaseMeter(pc, lp.effectiveVoltage)
 		} else {
 			mt := new(wrapper.ChargeMeter)
 			_ = lp.bus.Subscribe(evChargeCurrent, lp.evChargeCurrentWrappedMeterHandler)
@@ -372,11 +771,12 @@ func (lp *Loadpoint) configureChargerType(charger api.Charger) {
 			lp.chargedAtStartup = f
 		}
 	} else {
-		rt := wrapper.NewChargeRater(lp.log, lp.chargeMeter)
+		rt := wrapper.NewChargeRater(lp.log, lp.chargeMeter, lp.stateStore())
 		_ = lp.bus.Subscribe(evChargePower, rt.SetChargePower)
 		_ = lp.bus.Subscribe(evVehicleConnect, func() { rt.StartCharge(false) })
 		_ = lp.bus.Subscribe(evChargeStart, func() { rt.StartCharge(true) })
 		_ = lp.bus.Subscribe(evChargeStop, rt.StopCharge)
+		_ = lp.bus.Subscribe(evVehicleDisconnect, rt.Disconnect)
 		lp.chargeRater = rt
 	}
 
@@ -384,10 +784,11 @@ func (lp *Loadpoint) configureChargerType(charger api.Charger) {
 	if ct, ok := charger.(api.ChargeTimer); ok {
 		lp.chargeTimer = ct
 	} else {
-		ct := wrapper.NewChargeTimer()
+		ct := wrapper.NewChargeTimer(lp.log, lp.stateStore())
 		_ = lp.bus.Subscribe(evVehicleConnect, func() { ct.StartCharge(false) })
 		_ = lp.bus.Subscribe(evChargeStart, func() { ct.StartCharge(true) })
 		_ = lp.bus.Subscribe(evChargeStop, ct.StopCharge)
+		_ = lp.bus.Subscribe(evVehicleDisconnect, ct.Disconnect)
 		lp.chargeTimer = ct
 	}
 
@@ -402,6 +803,10 @@ func (lp *Loadpoint) pushEvent(event string) {
 
 // publish sends values to UI and databases
 func (lp *Loadpoint) publish(key string, val interface{}) {
+	if lp.metrics != nil {
+		lp.metrics.Update(key, val)
+	}
+
 	// test helper
 	if lp.uiChan == nil {
 		return
@@ -414,6 +819,9 @@ func (lp *Loadpoint) publish(key string, val interface{}) {
 func (lp *Loadpoint) evChargeStartHandler() {
 	lp.log.INFO.Println("start charging ->")
 	lp.pushEvent(evChargeStart)
+	lp.recordEvent(evChargeStart, nil)
+
+	lp.chargeStartedAt = lp.clock.Now()
 
 	lp.stopWakeUpTimer()
 
@@ -432,6 +840,7 @@ func (lp *Loadpoint) evChargeStartHandler() {
 func (lp *Loadpoint) evChargeStopHandler() {
 	lp.log.INFO.Println("stop charging <-")
 	lp.pushEvent(evChargeStop)
+	lp.recordEvent(evChargeStop, lp.getChargedEnergy())
 	if lp.enabled {
 		lp.startWakeUpTimer()
 	}
@@ -452,11 +861,22 @@ func (lp *Loadpoint) evChargeStopHandler() {
 // evVehicleConnectHandler sends external start event
 func (lp *Loadpoint) evVehicleConnectHandler() {
 	lp.log.INFO.Printf("car connected")
+	lp.recordEvent(evVehicleConnect, nil)
 
 	// energy
 	lp.sessionEnergy.Reset()
 	lp.sessionEnergy.Publish("session", lp)
 	lp.publish(keys.ChargedEnergy, lp.getChargedEnergy())
+	lp.setCostAlertFired(false)
+
+	// top-up charge
+	lp.minConnectActive = lp.MinConnectEnergy > 0
+
+	// discard site power samples from before connecting
+	lp.resetSitePowerSmoothing()
+
+	// max current detection
+	lp.startMaxCurrentDetection()
 
 	// duration
 	lp.connectedTime = lp.clock.Now()
@@ -475,6 +895,9 @@ func (lp *Loadpoint) evVehicleConnectHandler() {
 		lp.vehicleDefaultOrDetect()
 	}
 
+	// virtual vehicle soc is unknown after reconnecting
+	lp.resetVirtualSoc()
+
 	// immediately allow pv mode activity
 	lp.elapsePVTimer()
 
@@ -485,8 +908,12 @@ func (lp *Loadpoint) evVehicleConnectHandler() {
 // evVehicleDisconnectHandler sends external start event
 func (lp *Loadpoint) evVehicleDisconnectHandler() {
 	lp.log.INFO.Println("car disconnected")
+	lp.recordEvent(evVehicleDisconnect, lp.clock.Since(lp.connectedTime).Round(time.Second))
 
 	// session is persisted during evChargeStopHandler which runs before
+	lp.updateSession(func(session *session.Session) {
+		session.Disconnected = lp.clock.Now()
+	})
 	lp.clearSession()
 
 	// phases are unknown when vehicle disconnects
@@ -496,6 +923,9 @@ func (lp *Loadpoint) evVehicleDisconnectHandler() {
 	lp.sessionEnergy.Publish("session", lp)
 	lp.publish(keys.ChargedEnergy, lp.getChargedEnergy())
 	lp.publish(keys.ConnectedDuration, lp.clock.Since(lp.connectedTime).Round(time.Second))
+	lp.publishConnectDurationStats()
+	lp.pushSessionSummary()
+	lp.logSessionHistory()
 
 	// forget startup energy offset
 	lp.chargedAtStartup = 0
@@ -504,6 +934,15 @@ func (lp *Loadpoint) evVehicleDisconnectHandler() {
 	lp.setVehicleIdentifier("")
 	lp.stopVehicleDetection()
 
+	// remote session and one-time schedule end with vehicle connection
+	lp.clearRemoteSession()
+	lp.clearForceCharge()
+	lp.clearOneTimeSchedule()
+	lp.clearVehicleCommands()
+
+	// abort incomplete max current detection
+	lp.detectActive = false
+
 	// set default mode on disconnect
 	lp.defaultMode()
 
@@ -515,7 +954,7 @@ func (lp *Loadpoint) evVehicleDisconnectHandler() {
 
 	// reset session
 	lp.SetLimitSoc(0)
-	lp.SetLimitEnergy(0)
+	_ = lp.SetLimitEnergy(0)
 
 	// mark plan slot as inactive
 	// this will force a deletion of an outdated plan once plan time is expired in GetPlan()
@@ -543,7 +982,7 @@ func (lp *Loadpoint) evChargeCurrentHandler(current float64) {
 // If physical charge meter is present this handler is not used.
 // The actual value is published by the evChargeCurrentHandler
 func (lp *Loadpoint) evChargeCurrentWrappedMeterHandler(current float64) {
-	power := current * float64(lp.ActivePhases()) * Voltage
+	power := current * float64(lp.ActivePhases()) * lp.effectiveVoltage()
 
 	// if disabled we cannot be charging
 	if !lp.enabled || !lp.charging() {
@@ -561,16 +1000,25 @@ func (lp *Loadpoint) defaultMode() {
 	lp.RUnlock()
 
 	if mode != "" && mode != lp.GetMode() {
-		lp.SetMode(mode)
+		if err := lp.SetMode(mode); err != nil {
+			lp.log.ERROR.Println(err)
+		}
 	}
 }
 
 // Prepare loadpoint configuration by adding missing helper elements
-func (lp *Loadpoint) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Event, lpChan chan<- *Loadpoint) {
+func (lp *Loadpoint) Prepare(ctx context.Context, uiChan chan<- util.Param, pushChan chan<- push.Event, lpChan chan<- *Loadpoint) {
 	lp.uiChan = uiChan
 	lp.pushChan = pushChan
 	lp.lpChan = lpChan
 
+	lp.registerMetrics()
+
+	if lp.WatchdogTimeout > 0 {
+		lp.watchdogC = make(chan struct{}, 1)
+		go lp.watchdog(ctx)
+	}
+
 	// event handlers
 	_ = lp.bus.Subscribe(evChargeStart, lp.evChargeStartHandler)
 	_ = lp.bus.Subscribe(evChargeStop, lp.evChargeStopHandler)
@@ -610,6 +1058,9 @@ func (lp *Loadpoint) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Even
 		lp.publishChargerFeature(f)
 	}
 
+	// charger capabilities
+	lp.publishChargerCapabilities()
+
 	// charger icon
 	if c, ok := lp.charger.(api.IconDescriber); ok {
 		lp.publish(keys.ChargerIcon, c.Icon())
@@ -631,13 +1082,26 @@ func (lp *Loadpoint) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Even
 
 	// restored settings
 	lp.publish(keys.PlanTime, lp.planTime)
+	lp.publish(keys.DepartureTime, lp.departureTime)
 	lp.publish(keys.PlanEnergy, lp.planEnergy)
 	lp.publish(keys.LimitSoc, lp.limitSoc)
 	lp.publish(keys.LimitEnergy, lp.limitEnergy)
+	lp.publish(keys.SocTarget, lp.SocTarget)
+	lp.publish(keys.GridExportLimit, lp.GridExportLimit)
+	lp.publish(keys.LifetimeSolarEnergy, lp.lifetimeSolarEnergy)
+	lp.publish(keys.ContactorOps, lp.contactorOps)
+	lp.publish(keys.ContactorWearWarning, lp.contactorWearWarning)
+
+	// discover charger current step size before syncing charger state
+	lp.detectCurrentGranularity()
 
 	// read initial charger state to prevent immediately disabling charger
 	if enabled, err := lp.charger.Enabled(); err == nil {
-		if lp.enabled = enabled; enabled {
+		lp.chargerMu.Lock()
+		lp.enabled = enabled
+		lp.chargerMu.Unlock()
+
+		if enabled {
 			// set defined current for use by pv mode
 			_ = lp.setLimit(lp.effectiveMinCurrent())
 		}
@@ -653,6 +1117,9 @@ func (lp *Loadpoint) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Even
 
 // syncCharger updates charger status and synchronizes it with expectations
 func (lp *Loadpoint) syncCharger() error {
+	lp.chargerMu.Lock()
+	defer lp.chargerMu.Unlock()
+
 	enabled, err := lp.charger.Enabled()
 	if err != nil {
 		return err
@@ -714,18 +1181,34 @@ func (lp *Loadpoint) syncCharger() error {
 
 // setLimit applies charger current limits and enables/disables accordingly
 func (lp *Loadpoint) setLimit(chargeCurrent float64) error {
+	lp.chargerMu.Lock()
+	defer lp.chargerMu.Unlock()
+
+	// corrected for systematic charger current error
+	command := lp.correctedCurrent(chargeCurrent)
+
 	// full amps only?
 	if _, ok := lp.charger.(api.ChargerEx); !ok || lp.vehicleHasFeature(api.CoarseCurrent) {
 		chargeCurrent = math.Trunc(chargeCurrent)
+		command = math.Trunc(command)
+	}
+
+	// charger step size
+	chargeCurrent = lp.roundToCurrentStep(chargeCurrent)
+	command = lp.roundToCurrentStep(command)
+
+	changed := chargeCurrent != lp.chargeCurrent
+	if lp.CurrentFeedback {
+		changed = changed || command != lp.commandedCurrent
 	}
 
 	// set current
-	if chargeCurrent != lp.chargeCurrent && chargeCurrent >= lp.effectiveMinCurrent() {
+	if changed && chargeCurrent >= lp.effectiveMinCurrent() {
 		var err error
 		if charger, ok := lp.charger.(api.ChargerEx); ok {
-			err = charger.MaxCurrentMillis(chargeCurrent)
+			err = charger.MaxCurrentMillis(command)
 		} else {
-			err = lp.charger.MaxCurrent(int64(chargeCurrent))
+			err = lp.charger.MaxCurrent(int64(command))
 		}
 
 		if err != nil {
@@ -734,7 +1217,7 @@ func (lp *Loadpoint) setLimit(chargeCurrent float64) error {
 				// https://github.com/evcc-io/evcc/issues/8254
 				// wakeup vehicle
 				lp.log.DEBUG.Printf("max charge current: waking up vehicle")
-				if err := vv.WakeUp(); err != nil {
+				if err := lp.vehicleCommand("wake-up vehicle", vv.WakeUp); err != nil {
 					return fmt.Errorf("wake-up vehicle: %w", err)
 				}
 			}
@@ -742,8 +1225,13 @@ func (lp *Loadpoint) setLimit(chargeCurrent float64) error {
 			return fmt.Errorf("max charge current %.3gA: %w", chargeCurrent, err)
 		}
 
-		lp.log.DEBUG.Printf("max charge current: %.3gA", chargeCurrent)
+		if command != chargeCurrent {
+			lp.log.DEBUG.Printf("max charge current: %.3gA (corrected: %.3gA)", chargeCurrent, command)
+		} else {
+			lp.log.DEBUG.Printf("max charge current: %.3gA", chargeCurrent)
+		}
 		lp.chargeCurrent = chargeCurrent
+		lp.commandedCurrent = command
 		lp.bus.Publish(evChargeCurrent, chargeCurrent)
 	}
 
@@ -755,7 +1243,7 @@ func (lp *Loadpoint) setLimit(chargeCurrent float64) error {
 				// https://github.com/evcc-io/evcc/issues/8254
 				// wakeup vehicle
 				lp.log.DEBUG.Printf("charger %s: waking up vehicle", status[enabled])
-				if err := vv.WakeUp(); err != nil {
+				if err := lp.vehicleCommand("wake-up vehicle", vv.WakeUp); err != nil {
 					return fmt.Errorf("wake-up vehicle: %w", err)
 				}
 			}
@@ -767,6 +1255,8 @@ func (lp *Loadpoint) setLimit(chargeCurrent float64) error {
 		lp.enabled = enabled
 		lp.publish(keys.Enabled, lp.enabled)
 		lp.chargerSwitched = lp.clock.Now()
+		lp.recordToggle()
+		lp.recordContactorOp()
 
 		lp.bus.Publish(evChargeCurrent, chargeCurrent)
 
@@ -801,8 +1291,7 @@ func (lp *Loadpoint) setStatus(status api.ChargeStatus) {
 
 // socBasedPlanning returns true if vehicle soc (optionally from charger) and capacity are available
 func (lp *Loadpoint) socBasedPlanning() bool {
-	v := lp.GetVehicle()
-	return (v != nil && v.Capacity() > 0) && (lp.vehicleHasSoc() || lp.vehicleSoc > 0)
+	return lp.vehicleCapacity() > 0 && (lp.vehicleHasSoc() || lp.vehicleSoc > 0)
 }
 
 // vehicleHasSoc returns true if active vehicle supports returning soc, i.e. it is not an offline vehicle
@@ -850,7 +1339,7 @@ func (lp *Loadpoint) minSocNotReached() bool {
 		return active
 	}
 
-	minEnergy := v.Capacity() * float64(minSoc) / 100 / soc.ChargeEfficiency
+	minEnergy := lp.vehicleCapacity() * float64(minSoc) / 100 / soc.ChargeEfficiency
 	return minEnergy > 0 && lp.getChargedEnergy() < minEnergy
 }
 
@@ -901,7 +1390,10 @@ func statusEvents(prevStatus, status api.ChargeStatus) []string {
 
 // updateChargerStatus updates charger status and detects car connected/disconnected events
 func (lp *Loadpoint) updateChargerStatus() error {
-	status, err := lp.charger.Status()
+	status, err := backoff.RetryWithData(lp.charger.Status, lp.readBackOff(func() backoff.BackOff {
+		return new(backoff.StopBackOff) // no retry by default
+	}))
+	lp.chargerHealthCheck(err)
 	if err != nil {
 		return err
 	}
@@ -1040,6 +1532,13 @@ func (lp *Loadpoint) fastCharging() error {
 
 // pvScalePhases switches phases if necessary and returns if switch occurred
 func (lp *Loadpoint) pvScalePhases(sitePower, minCurrent, maxCurrent float64) bool {
+	// protect contactor from rapid switching
+	remaining := lp.phaseSwitchCooldownRemaining()
+	lp.publish(keys.PhaseSwitchCooldownRemaining, remaining)
+	if remaining > 0 {
+		return false
+	}
+
 	phases := lp.GetPhases()
 
 	// observed phase state inconsistency
@@ -1060,13 +1559,19 @@ func (lp *Loadpoint) pvScalePhases(sitePower, minCurrent, maxCurrent float64) bo
 	scalable := (sitePower > 0 || !lp.enabled) && activePhases > 1 && lp.configuredPhases < 3
 
 	// scale down phases
-	if targetCurrent := powerToCurrent(availablePower, activePhases); targetCurrent < minCurrent && scalable {
-		lp.log.DEBUG.Printf("available power %.0fW < %.0fW min %dp threshold", availablePower, float64(activePhases)*Voltage*minCurrent, activePhases)
+	if targetCurrent := lp.powerToCurrent(availablePower, activePhases); targetCurrent < minCurrent && scalable {
+		lp.log.DEBUG.Printf("available power %.0fW < %.0fW min %dp threshold", availablePower, float64(activePhases)*lp.effectiveVoltage()*minCurrent, activePhases)
 
 		if !lp.charging() { // scale immediately if not charging
 			lp.phaseTimer = elapsed
 		}
 
+		// scale immediately if 1p allows to continue charging
+		downgrade := lp.PhaseAutoDowngrade && lp.powerToCurrent(availablePower, 1) >= minCurrent
+		if downgrade {
+			lp.phaseTimer = elapsed
+		}
+
 		if lp.phaseTimer.IsZero() {
 			lp.log.DEBUG.Printf("start phase %s timer", phaseScale1p)
 			lp.phaseTimer = lp.clock.Now()
@@ -1077,6 +1582,8 @@ func (lp *Loadpoint) pvScalePhases(sitePower, minCurrent, maxCurrent float64) bo
 		if elapsed := lp.clock.Since(lp.phaseTimer); elapsed >= lp.Disable.Delay {
 			if err := lp.scalePhases(1); err != nil {
 				lp.log.ERROR.Println(err)
+			} else {
+				lp.setPhaseDowngrade(downgrade)
 			}
 			return true
 		}
@@ -1085,12 +1592,12 @@ func (lp *Loadpoint) pvScalePhases(sitePower, minCurrent, maxCurrent float64) bo
 	}
 
 	maxPhases := lp.maxActivePhases()
-	target1pCurrent := powerToCurrent(availablePower, 1)
+	target1pCurrent := lp.powerToCurrent(availablePower, 1)
 	scalable = maxPhases > 1 && phases < maxPhases && target1pCurrent > maxCurrent
 
 	// scale up phases
-	if targetCurrent := powerToCurrent(availablePower, maxPhases); targetCurrent >= minCurrent && scalable {
-		lp.log.DEBUG.Printf("available power %.0fW > %.0fW min %dp threshold", availablePower, 3*Voltage*minCurrent, maxPhases)
+	if targetCurrent := lp.powerToCurrent(availablePower, maxPhases); targetCurrent >= minCurrent && scalable {
+		lp.log.DEBUG.Printf("available power %.0fW > %.0fW min %dp threshold", availablePower, 3*lp.effectiveVoltage()*minCurrent, maxPhases)
 
 		if !lp.charging() { // scale immediately if not charging
 			lp.phaseTimer = elapsed
@@ -1106,6 +1613,8 @@ func (lp *Loadpoint) pvScalePhases(sitePower, minCurrent, maxCurrent float64) bo
 		if elapsed := lp.clock.Since(lp.phaseTimer); elapsed >= lp.Enable.Delay {
 			if err := lp.scalePhases(3); err != nil {
 				lp.log.ERROR.Println(err)
+			} else {
+				lp.setPhaseDowngrade(false)
 			}
 			return true
 		}
@@ -1149,19 +1658,35 @@ func (lp *Loadpoint) pvMaxCurrent(mode api.ChargeMode, sitePower float64, batter
 	minCurrent := lp.effectiveMinCurrent()
 	maxCurrent := lp.effectiveMaxCurrent()
 
+	// keep allowed grid export
+	sitePower = lp.gridExportLimitedSitePower(sitePower)
+
 	// switch phases up/down
-	if lp.hasPhaseSwitching() {
+	if lp.hasPhaseSwitching() && !lp.socScalePhases() {
 		_ = lp.pvScalePhases(sitePower, minCurrent, maxCurrent)
 	}
 
 	// calculate target charge current from delta power and actual current
 	effectiveCurrent := lp.effectiveCurrent()
 	activePhases := lp.ActivePhases()
-	deltaCurrent := powerToCurrent(-sitePower, activePhases)
+	deltaCurrent := lp.powerToCurrent(-sitePower, activePhases)
 	targetCurrent := max(effectiveCurrent+deltaCurrent, 0)
 
 	lp.log.DEBUG.Printf("pv charge current: %.3gA = %.3gA + %.3gA (%.0fW @ %dp)", targetCurrent, effectiveCurrent, deltaCurrent, sitePower, activePhases)
 
+	// in MinPV mode with soc scaling fall back to pure PV if the scaled min current is not covered
+	if mode == api.ModeMinPV && lp.MinPVSocScaling && targetCurrent < minCurrent {
+		if scaled := minPVSocScaledCurrent(minCurrent, lp.vehicleSoc, lp.effectiveLimitSoc()); targetCurrent+scaled < minCurrent {
+			lp.log.DEBUG.Printf("minpv soc scaling: min current reduced to %.3gA at %.1f%%", scaled, lp.vehicleSoc)
+			mode = api.ModePV
+		}
+	}
+
+	// below pv min soc charge at least minCurrent
+	if lp.pvMinSocActive() && targetCurrent < minCurrent {
+		return minCurrent
+	}
+
 	// in MinPV mode or under special conditions return at least minCurrent
 	if (mode == api.ModeMinPV || batteryStart || batteryBuffered && lp.charging()) && targetCurrent < minCurrent {
 		return minCurrent
@@ -1172,28 +1697,40 @@ func (lp *Loadpoint) pvMaxCurrent(mode api.ChargeMode, sitePower float64, batter
 		if !lp.phaseTimer.IsZero() {
 			// calculate site power after a phase switch from activePhases phases -> 1 phase
 			// notes: activePhases can be 1, 2 or 3 and phaseTimer can only be active if lp current is already at minCurrent
-			projectedSitePower -= Voltage * minCurrent * float64(activePhases-1)
+			projectedSitePower -= lp.effectiveVoltage() * minCurrent * float64(activePhases-1)
 		}
 		// kick off disable sequence
-		if projectedSitePower >= lp.Disable.Threshold {
-			lp.log.DEBUG.Printf("projected site power %.0fW >= %.0fW disable threshold", projectedSitePower, lp.Disable.Threshold)
+		if disableThreshold := lp.pvThreshold(lp.Disable); projectedSitePower >= disableThreshold {
+			lp.log.DEBUG.Printf("projected site power %.0fW >= %.0fW disable threshold", projectedSitePower, disableThreshold)
+
+			delay := lp.effectiveDisableDelay()
 
 			if lp.pvTimer.IsZero() {
-				lp.log.DEBUG.Printf("pv disable timer start: %v", lp.Disable.Delay)
+				lp.log.DEBUG.Printf("pv disable timer start: %v", delay)
 				lp.pvTimer = lp.clock.Now()
 			}
 
-			lp.publishTimer(pvTimer, lp.Disable.Delay, pvDisable)
+			lp.publishTimer(pvTimer, delay, pvDisable)
 
 			elapsed := lp.clock.Since(lp.pvTimer)
-			if elapsed >= lp.Disable.Delay {
+			if elapsed >= delay {
+				if lp.toggleLimitActive() {
+					lp.log.DEBUG.Printf("pv disable timer elapsed: holding min current (%d toggles/h)", lp.MaxToggles)
+					return minCurrent
+				}
+
+				if lp.minChargeIntervalActive() {
+					lp.log.DEBUG.Printf("pv disable timer elapsed: holding min current for %v min charge interval", lp.MinChargeInterval)
+					return minCurrent
+				}
+
 				lp.log.DEBUG.Println("pv disable timer elapsed")
 				return 0
 			}
 
 			// suppress duplicate log message after timer started
 			if elapsed > time.Second {
-				lp.log.DEBUG.Printf("pv disable timer remaining: %v", (lp.Disable.Delay - elapsed).Round(time.Second))
+				lp.log.DEBUG.Printf("pv disable timer remaining: %v", (delay - elapsed).Round(time.Second))
 			}
 		} else {
 			// reset timer
@@ -1206,9 +1743,10 @@ func (lp *Loadpoint) pvMaxCurrent(mode api.ChargeMode, sitePower float64, batter
 
 	if mode == api.ModePV && !lp.enabled {
 		// kick off enable sequence
-		if (lp.Enable.Threshold == 0 && targetCurrent >= minCurrent) ||
-			(lp.Enable.Threshold != 0 && sitePower <= lp.Enable.Threshold) {
-			lp.log.DEBUG.Printf("site power %.0fW <= %.0fW enable threshold", sitePower, lp.Enable.Threshold)
+		enableThreshold := lp.pvThreshold(lp.Enable)
+		if (enableThreshold == 0 && targetCurrent >= minCurrent) ||
+			(enableThreshold != 0 && sitePower <= enableThreshold) {
+			lp.log.DEBUG.Printf("site power %.0fW <= %.0fW enable threshold", sitePower, enableThreshold)
 
 			if lp.pvTimer.IsZero() {
 				lp.log.DEBUG.Printf("pv enable timer start: %v", lp.Enable.Delay)
@@ -1247,15 +1785,14 @@ func (lp *Loadpoint) pvMaxCurrent(mode api.ChargeMode, sitePower float64, batter
 
 // UpdateChargePower updates charge meter power
 func (lp *Loadpoint) UpdateChargePower() {
-	bo := backoff.NewExponentialBackOff()
-	bo.MaxElapsedTime = time.Second
-
 	if err := backoff.Retry(func() error {
 		value, err := lp.chargeMeter.CurrentPower()
 		if err != nil {
 			return err
 		}
 
+		value = lp.calibratedPower(value)
+
 		lp.Lock()
 		lp.chargePower = value // update value if no error
 		lp.Unlock()
@@ -1270,7 +1807,9 @@ func (lp *Loadpoint) UpdateChargePower() {
 		}
 
 		return nil
-	}, bo); err != nil {
+	}, lp.readBackOff(func() backoff.BackOff {
+		return bo()
+	})); err != nil {
 		lp.log.ERROR.Printf("charge meter: %v", err)
 	}
 }
@@ -1294,10 +1833,17 @@ func (lp *Loadpoint) updateChargeCurrents() {
 	lp.log.DEBUG.Printf("charge currents: %.3gA", lp.chargeCurrents)
 	lp.publish(keys.ChargeCurrents, lp.chargeCurrents)
 
+	lp.updatePhaseImbalance()
+
 	if lp.charging() && lp.phaseSwitchCompleted() {
+		threshold := lp.MinActiveCurrent
+		if threshold <= 0 {
+			threshold = minActiveCurrent
+		}
+
 		var phases int
 		for _, i := range lp.chargeCurrents {
-			if i > minActiveCurrent {
+			if i > threshold {
 				phases++
 			}
 		}
@@ -1363,6 +1909,7 @@ func (lp *Loadpoint) publishChargeProgress() {
 			if telemetry.Enabled() && added > 0 {
 				telemetry.UpdateEnergy(added, addedGreen)
 			}
+			lp.addLifetimeSolarEnergy(addedGreen)
 		}
 	} else {
 		lp.log.ERROR.Printf("charge rater: %v", err)
@@ -1380,9 +1927,26 @@ func (lp *Loadpoint) publishChargeProgress() {
 	// TODO deprecated: use sessionEnergy instead
 	lp.publish(keys.ChargedEnergy, lp.getChargedEnergy())
 	lp.publish(keys.ChargeDuration, lp.chargeDuration)
+
+	lp.publishLimitEnergyRemaining()
 	if _, ok := lp.chargeMeter.(api.MeterEnergy); ok {
 		lp.publish(keys.ChargeTotalImport, lp.chargeMeterTotal())
 	}
+
+	lp.checkCostAlert()
+}
+
+// publishLimitEnergyRemaining publishes the remaining session energy until limit if it has changed
+func (lp *Loadpoint) publishLimitEnergyRemaining() {
+	remaining, ok := lp.remainingLimitEnergy()
+	if !ok {
+		remaining = 0
+	}
+
+	if remaining != lp.limitEnergyRemaining {
+		lp.limitEnergyRemaining = remaining
+		lp.publish(keys.LimitEnergyRemaining, remaining)
+	}
 }
 
 // publish state of charge, remaining charge duration and range
@@ -1415,9 +1979,13 @@ func (lp *Loadpoint) publishSocAndRange() {
 			return
 		}
 
+		lp.checkSocEstimate(f)
+
 		lp.vehicleSoc = f
 		lp.log.DEBUG.Printf("vehicle soc: %.0f%%", lp.vehicleSoc)
 		lp.publish(keys.VehicleSoc, lp.vehicleSoc)
+		lp.recordVehicleData()
+		lp.publishSocWebhook(lp.vehicleSoc)
 
 		// vehicle target soc
 		// TODO take vehicle api limits into account
@@ -1443,6 +2011,8 @@ func (lp *Loadpoint) publishSocAndRange() {
 
 		lp.SetRemainingEnergy(1e3 * lp.socEstimator.RemainingChargeEnergy(limitSoc))
 
+		lp.updateVehicleCurrentLimit()
+
 		// range
 		if vs, ok := lp.GetVehicle().(api.VehicleRange); ok {
 			if rng, err := vs.Range(); err == nil {
@@ -1455,6 +2025,8 @@ func (lp *Loadpoint) publishSocAndRange() {
 
 		// trigger message after variables are updated
 		lp.bus.Publish(evVehicleSoc, f)
+	} else if lp.charging() && lp.Soc.Poll.ChargingInterval > 0 {
+		lp.estimateSoc()
 	}
 }
 
@@ -1495,7 +2067,11 @@ func (lp *Loadpoint) stopWakeUpTimer() {
 
 // chargerUpdateCompleted returns true if enable command should be already processed by the charger (so we can try to sync charger and loadpoint)
 func (lp *Loadpoint) chargerUpdateCompleted() bool {
-	return time.Since(lp.chargerSwitched) > chargerSwitchDuration
+	duration := chargerSwitchDuration
+	if lp.chargerHasFeature(api.RapidCurrent) {
+		duration = rapidChargerSwitchDuration
+	}
+	return time.Since(lp.chargerSwitched) > duration
 }
 
 // phaseSwitchCompleted returns true if phase switch command should be already processed by the charger (so we can try to sync charger and loadpoint and are able to measure currents)
@@ -1506,11 +2082,20 @@ func (lp *Loadpoint) phaseSwitchCompleted() bool {
 // Update is the main control function. It reevaluates meters and charger state
 func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batteryStart bool, greenShare float64, effPrice, effCo2 *float64) {
 	lp.publish(keys.SmartCostActive, autoCharge)
+	lp.feedWatchdog()
 	lp.processTasks()
 
 	// read and publish meters first- charge power has already been updated by the site
 	lp.updateChargeVoltages()
 	lp.updateChargeCurrents()
+	lp.inferPhasesFromPower()
+	lp.updateCurrentFeedback()
+	lp.logCurrentMismatch()
+	lp.updateChargerTemperature()
+	lp.updatePvCapacityEstimate(sitePower)
+
+	// smooth noisy site meter readings
+	sitePower = lp.smoothedSitePower(sitePower)
 
 	lp.sessionEnergy.SetEnvironment(greenShare, effPrice, effCo2)
 
@@ -1520,7 +2105,9 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 
 	// update progress and soc before status is updated
 	lp.publishChargeProgress()
+	lp.publishNightRate()
 	lp.PublishEffectiveValues()
+	lp.annualSummary()
 
 	// read and publish status
 	if err := lp.updateChargerStatus(); err != nil {
@@ -1544,8 +2131,12 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 
 	// publish soc after updating charger status to make sure
 	// initial update of connected state matches charger status
+	lp.updateVirtualSoc()
 	lp.publishSocAndRange()
 
+	// retry failed vehicle commands
+	lp.retryVehicleCommands()
+
 	// sync settings with charger
 	if err := lp.syncCharger(); err != nil {
 		lp.log.ERROR.Printf("charger: %v", err)
@@ -1561,9 +2152,33 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 	mode := lp.GetMode()
 	lp.publish(keys.Mode, mode)
 
+	// off-peak window charges immediately, pv otherwise
+	if mode == api.ModeOffPeak {
+		mode = lp.offPeakMode()
+	} else {
+		lp.publish(keys.OffPeakActive, false)
+	}
+
+	// no charging on holidays
+	if lp.holidayModeActive() {
+		mode = api.ModeOff
+	}
+
+	// explicit user overrides take precedence over charge mode and holidays:
+	// remotely started sessions and forced charging charge immediately
+	if lp.remoteSessionActive() || lp.GetForceCharge() {
+		mode = api.ModeNow
+	}
+
 	// update and publish plan without being short-circuited by modes etc.
 	plannerActive := lp.plannerActive()
 
+	// request higher supply voltage if mandatory charging is limited by under-voltage
+	lp.updateVoltageBoost()
+
+	// disable charging once after updates have stalled
+	watchdogExpired := lp.watchdogExpired()
+
 	// execute loading strategy
 	switch {
 	case !lp.connected():
@@ -1571,6 +2186,9 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 		// https://github.com/evcc-io/evcc/issues/105
 		err = lp.setLimit(0)
 
+	case watchdogExpired:
+		err = lp.setLimit(0)
+
 	case lp.scalePhasesRequired():
 		err = lp.scalePhases(lp.configuredPhases)
 
@@ -1581,6 +2199,14 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 	case mode == api.ModeOff:
 		err = lp.setLimit(0)
 
+	case lp.maxSessionDurationReached():
+		lp.log.DEBUG.Printf("max session duration reached: %v", lp.MaxSessionDuration)
+		err = lp.setLimit(0)
+
+	// external current control bypasses all pv, soc and planner logic
+	case lp.ExternalCurrentControl:
+		err = lp.externalCurrentControl()
+
 	// minimum or target charging
 	case lp.minSocNotReached() || plannerActive:
 		err = lp.fastCharging()
@@ -1595,10 +2221,42 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 		lp.log.DEBUG.Printf("limitSoc reached: %.1f%% > %d%%", lp.vehicleSoc, lp.effectiveLimitSoc())
 		err = lp.disableUnlessClimater()
 
+	// max current detection after first connect regardless of mode
+	case lp.maxCurrentDetectActive():
+		err = lp.setLimit(lp.detectCurrent)
+		lp.resetPhaseTimer()
+		lp.elapsePVTimer() // let PV mode disable immediately afterwards
+
+	// top-up charge after connecting regardless of mode
+	case lp.minConnectEnergyActive():
+		err = lp.setLimit(lp.effectiveMinCurrent())
+		lp.resetPhaseTimer()
+		lp.elapsePVTimer() // let PV mode disable immediately afterwards
+
 	// immediate charging- must be placed after limits are evaluated
 	case mode == api.ModeNow:
 		err = lp.fastCharging()
 
+	// flexible schedule replaces all other charge modes
+	case len(lp.FlexSchedule) > 0:
+		err = lp.flexCharging()
+
+	// green charging- pv surplus, cheap tariff or departure urgency
+	case mode == api.ModeGreenCharge:
+		err = lp.greenCharging(sitePower, autoCharge, batteryBuffered, batteryStart)
+
+	// scheduled charging- constant current to reach limit soc by departure time
+	case mode == api.ModeScheduled:
+		err = lp.scheduledCharging(sitePower, batteryBuffered, batteryStart)
+
+	// cheap charging- cheapest tariff windows to reach limit soc by departure time
+	case mode == api.ModeCheap:
+		err = lp.cheapCharging()
+
+	// grid friendly charging- grid frequency indicates surplus generation
+	case mode == api.ModeGridFriendly:
+		err = lp.gridFriendlyCharging()
+
 	case mode == api.ModeMinPV || mode == api.ModePV:
 		// cheap tariff
 		if autoCharge && lp.EffectivePlanTime().IsZero() {
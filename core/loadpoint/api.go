@@ -1,6 +1,8 @@
 package loadpoint
 
 import (
+	"context"
+	"io"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
@@ -44,7 +46,7 @@ type API interface {
 	// GetMode returns the charge mode
 	GetMode() api.ChargeMode
 	// SetMode sets the charge mode
-	SetMode(api.ChargeMode)
+	SetMode(api.ChargeMode) error
 	// GetPhases returns the enabled phases
 	GetPhases() int
 	// SetPhases sets the enabled phases
@@ -56,10 +58,14 @@ type API interface {
 	GetLimitSoc() int
 	// SetLimitSoc sets the session limit soc
 	SetLimitSoc(soc int)
+	// GetSocTarget returns the default soc target
+	GetSocTarget() int
+	// SetSocTarget sets the default soc target
+	SetSocTarget(soc int) error
 	// GetLimitEnergy returns the session limit energy
 	GetLimitEnergy() float64
 	// SetLimitEnergy sets the session limit energy
-	SetLimitEnergy(energy float64)
+	SetLimitEnergy(energy float64) error
 
 	//
 	// effective values
@@ -84,6 +90,12 @@ type API interface {
 	GetPlanEnergy() (time.Time, float64)
 	// SetPlanEnergy sets the charge plan energy
 	SetPlanEnergy(time.Time, float64) error
+	// SetOneTimeSchedule sets a plan departure and soc target for a single session
+	SetOneTimeSchedule(time.Time, float64) error
+	// GetDepartureTime returns the departure time for scheduled charging
+	GetDepartureTime() time.Time
+	// SetDepartureTime sets the departure time for scheduled charging
+	SetDepartureTime(time.Time) error
 	// GetPlanGoal returns the plan goal and if the goal is soc based
 	GetPlanGoal() (float64, bool)
 	// GetPlanRequiredDuration returns required duration of plan to reach the goal from current state
@@ -104,6 +116,16 @@ type API interface {
 
 	// RemoteControl sets remote status demand
 	RemoteControl(string, RemoteDemand)
+	// RemoteStart starts a remotely authorized session
+	RemoteStart(token string) error
+	// GetForceCharge returns if charging is forced until the vehicle disconnects
+	GetForceCharge() bool
+	// SetForceCharge forces charging regardless of mode until the vehicle disconnects
+	SetForceCharge(bool)
+	// GetExternalCurrent returns the externally commanded charge current
+	GetExternalCurrent() int64
+	// SetExternalCurrent sets the externally commanded charge current
+	SetExternalCurrent(int64) error
 
 	//
 	// smart grid charging
@@ -124,6 +146,10 @@ type API interface {
 	GetChargePower() float64
 	// GetChargePowerFlexibility returns the flexible amount of current charging power
 	GetChargePowerFlexibility() float64
+	// GetLifetimeSolarEnergy returns the solar energy delivered to vehicles since installation in kWh
+	GetLifetimeSolarEnergy() float64
+	// ResetLifetimeSolarEnergy resets the lifetime solar energy
+	ResetLifetimeSolarEnergy()
 
 	//
 	// charge progress
@@ -146,4 +172,13 @@ type API interface {
 	SetVehicle(vehicle api.Vehicle)
 	// StartVehicleDetection allows triggering vehicle detection for debugging purposes
 	StartVehicleDetection()
+	// ExportVehicleData writes sessions, soc history and charging curve in csv or json format
+	ExportVehicleData(ctx context.Context, w io.Writer, format string) error
+
+	//
+	// events
+	//
+
+	// EventHistory returns the recent loadpoint events
+	EventHistory() []EventRecord
 }
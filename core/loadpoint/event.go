@@ -0,0 +1,10 @@
+package loadpoint
+
+import "time"
+
+// EventRecord is a loadpoint event with its time of occurrence
+type EventRecord struct {
+	Time  time.Time `json:"time"`
+	Event string    `json:"event"`
+	Data  any       `json:"data,omitempty"`
+}
@@ -10,6 +10,8 @@
 package loadpoint
 
 import (
+	context "context"
+	io "io"
 	reflect "reflect"
 	time "time"
 
@@ -110,6 +112,34 @@ func (mr *MockAPIMockRecorder) EffectivePriority() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EffectivePriority", reflect.TypeOf((*MockAPI)(nil).EffectivePriority))
 }
 
+// EventHistory mocks base method.
+func (m *MockAPI) EventHistory() []EventRecord {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventHistory")
+	ret0, _ := ret[0].([]EventRecord)
+	return ret0
+}
+
+// EventHistory indicates an expected call of EventHistory.
+func (mr *MockAPIMockRecorder) EventHistory() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventHistory", reflect.TypeOf((*MockAPI)(nil).EventHistory))
+}
+
+// ExportVehicleData mocks base method.
+func (m *MockAPI) ExportVehicleData(arg0 context.Context, arg1 io.Writer, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportVehicleData", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportVehicleData indicates an expected call of ExportVehicleData.
+func (mr *MockAPIMockRecorder) ExportVehicleData(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportVehicleData", reflect.TypeOf((*MockAPI)(nil).ExportVehicleData), arg0, arg1, arg2)
+}
+
 // GetChargePower mocks base method.
 func (m *MockAPI) GetChargePower() float64 {
 	m.ctrl.T.Helper()
@@ -138,6 +168,20 @@ func (mr *MockAPIMockRecorder) GetChargePowerFlexibility() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChargePowerFlexibility", reflect.TypeOf((*MockAPI)(nil).GetChargePowerFlexibility))
 }
 
+// GetDepartureTime mocks base method.
+func (m *MockAPI) GetDepartureTime() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartureTime")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// GetDepartureTime indicates an expected call of GetDepartureTime.
+func (mr *MockAPIMockRecorder) GetDepartureTime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartureTime", reflect.TypeOf((*MockAPI)(nil).GetDepartureTime))
+}
+
 // GetDisableThreshold mocks base method.
 func (m *MockAPI) GetDisableThreshold() float64 {
 	m.ctrl.T.Helper()
@@ -166,6 +210,48 @@ func (mr *MockAPIMockRecorder) GetEnableThreshold() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnableThreshold", reflect.TypeOf((*MockAPI)(nil).GetEnableThreshold))
 }
 
+// GetExternalCurrent mocks base method.
+func (m *MockAPI) GetExternalCurrent() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExternalCurrent")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// GetExternalCurrent indicates an expected call of GetExternalCurrent.
+func (mr *MockAPIMockRecorder) GetExternalCurrent() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExternalCurrent", reflect.TypeOf((*MockAPI)(nil).GetExternalCurrent))
+}
+
+// GetForceCharge mocks base method.
+func (m *MockAPI) GetForceCharge() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForceCharge")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// GetForceCharge indicates an expected call of GetForceCharge.
+func (mr *MockAPIMockRecorder) GetForceCharge() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForceCharge", reflect.TypeOf((*MockAPI)(nil).GetForceCharge))
+}
+
+// GetLifetimeSolarEnergy mocks base method.
+func (m *MockAPI) GetLifetimeSolarEnergy() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLifetimeSolarEnergy")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetLifetimeSolarEnergy indicates an expected call of GetLifetimeSolarEnergy.
+func (mr *MockAPIMockRecorder) GetLifetimeSolarEnergy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLifetimeSolarEnergy", reflect.TypeOf((*MockAPI)(nil).GetLifetimeSolarEnergy))
+}
+
 // GetLimitEnergy mocks base method.
 func (m *MockAPI) GetLimitEnergy() float64 {
 	m.ctrl.T.Helper()
@@ -365,6 +451,20 @@ func (mr *MockAPIMockRecorder) GetSmartCostLimit() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSmartCostLimit", reflect.TypeOf((*MockAPI)(nil).GetSmartCostLimit))
 }
 
+// GetSocTarget mocks base method.
+func (m *MockAPI) GetSocTarget() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSocTarget")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetSocTarget indicates an expected call of GetSocTarget.
+func (mr *MockAPIMockRecorder) GetSocTarget() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSocTarget", reflect.TypeOf((*MockAPI)(nil).GetSocTarget))
+}
+
 // GetStatus mocks base method.
 func (m *MockAPI) GetStatus() api.ChargeStatus {
 	m.ctrl.T.Helper()
@@ -445,6 +545,46 @@ func (mr *MockAPIMockRecorder) RemoteControl(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoteControl", reflect.TypeOf((*MockAPI)(nil).RemoteControl), arg0, arg1)
 }
 
+// RemoteStart mocks base method.
+func (m *MockAPI) RemoteStart(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoteStart", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoteStart indicates an expected call of RemoteStart.
+func (mr *MockAPIMockRecorder) RemoteStart(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoteStart", reflect.TypeOf((*MockAPI)(nil).RemoteStart), arg0)
+}
+
+// ResetLifetimeSolarEnergy mocks base method.
+func (m *MockAPI) ResetLifetimeSolarEnergy() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResetLifetimeSolarEnergy")
+}
+
+// ResetLifetimeSolarEnergy indicates an expected call of ResetLifetimeSolarEnergy.
+func (mr *MockAPIMockRecorder) ResetLifetimeSolarEnergy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLifetimeSolarEnergy", reflect.TypeOf((*MockAPI)(nil).ResetLifetimeSolarEnergy))
+}
+
+// SetDepartureTime mocks base method.
+func (m *MockAPI) SetDepartureTime(arg0 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDepartureTime", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDepartureTime indicates an expected call of SetDepartureTime.
+func (mr *MockAPIMockRecorder) SetDepartureTime(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDepartureTime", reflect.TypeOf((*MockAPI)(nil).SetDepartureTime), arg0)
+}
+
 // SetDisableThreshold mocks base method.
 func (m *MockAPI) SetDisableThreshold(arg0 float64) {
 	m.ctrl.T.Helper()
@@ -469,10 +609,38 @@ func (mr *MockAPIMockRecorder) SetEnableThreshold(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEnableThreshold", reflect.TypeOf((*MockAPI)(nil).SetEnableThreshold), arg0)
 }
 
+// SetExternalCurrent mocks base method.
+func (m *MockAPI) SetExternalCurrent(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExternalCurrent", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetExternalCurrent indicates an expected call of SetExternalCurrent.
+func (mr *MockAPIMockRecorder) SetExternalCurrent(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExternalCurrent", reflect.TypeOf((*MockAPI)(nil).SetExternalCurrent), arg0)
+}
+
+// SetForceCharge mocks base method.
+func (m *MockAPI) SetForceCharge(arg0 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetForceCharge", arg0)
+}
+
+// SetForceCharge indicates an expected call of SetForceCharge.
+func (mr *MockAPIMockRecorder) SetForceCharge(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetForceCharge", reflect.TypeOf((*MockAPI)(nil).SetForceCharge), arg0)
+}
+
 // SetLimitEnergy mocks base method.
-func (m *MockAPI) SetLimitEnergy(arg0 float64) {
+func (m *MockAPI) SetLimitEnergy(arg0 float64) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetLimitEnergy", arg0)
+	ret := m.ctrl.Call(m, "SetLimitEnergy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // SetLimitEnergy indicates an expected call of SetLimitEnergy.
@@ -522,9 +690,11 @@ func (mr *MockAPIMockRecorder) SetMinCurrent(arg0 any) *gomock.Call {
 }
 
 // SetMode mocks base method.
-func (m *MockAPI) SetMode(arg0 api.ChargeMode) {
+func (m *MockAPI) SetMode(arg0 api.ChargeMode) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetMode", arg0)
+	ret := m.ctrl.Call(m, "SetMode", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // SetMode indicates an expected call of SetMode.
@@ -533,6 +703,20 @@ func (mr *MockAPIMockRecorder) SetMode(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMode", reflect.TypeOf((*MockAPI)(nil).SetMode), arg0)
 }
 
+// SetOneTimeSchedule mocks base method.
+func (m *MockAPI) SetOneTimeSchedule(arg0 time.Time, arg1 float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOneTimeSchedule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOneTimeSchedule indicates an expected call of SetOneTimeSchedule.
+func (mr *MockAPIMockRecorder) SetOneTimeSchedule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOneTimeSchedule", reflect.TypeOf((*MockAPI)(nil).SetOneTimeSchedule), arg0, arg1)
+}
+
 // SetPhases mocks base method.
 func (m *MockAPI) SetPhases(arg0 int) error {
 	m.ctrl.T.Helper()
@@ -585,6 +769,20 @@ func (mr *MockAPIMockRecorder) SetSmartCostLimit(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSmartCostLimit", reflect.TypeOf((*MockAPI)(nil).SetSmartCostLimit), arg0)
 }
 
+// SetSocTarget mocks base method.
+func (m *MockAPI) SetSocTarget(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSocTarget", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSocTarget indicates an expected call of SetSocTarget.
+func (mr *MockAPIMockRecorder) SetSocTarget(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSocTarget", reflect.TypeOf((*MockAPI)(nil).SetSocTarget), arg0)
+}
+
 // SetVehicle mocks base method.
 func (m *MockAPI) SetVehicle(arg0 api.Vehicle) {
 	m.ctrl.T.Helper()
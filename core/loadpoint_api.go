@@ -42,15 +42,18 @@ func (lp *Loadpoint) setMode(mode api.ChargeMode) {
 }
 
 // SetMode sets loadpoint charge mode
-func (lp *Loadpoint) SetMode(mode api.ChargeMode) {
+func (lp *Loadpoint) SetMode(mode api.ChargeMode) error {
+	mode, err := api.ChargeModeString(mode.String())
+	if err == nil && mode == api.ModeEmpty {
+		err = errors.New("empty value")
+	}
+	if err != nil {
+		return fmt.Errorf("invalid charge mode: %w", err)
+	}
+
 	lp.Lock()
 	defer lp.Unlock()
 
-	if _, err := api.ChargeModeString(mode.String()); err != nil {
-		lp.log.ERROR.Printf("invalid charge mode: %s", string(mode))
-		return
-	}
-
 	lp.log.DEBUG.Printf("set charge mode: %s", string(mode))
 
 	// apply immediately
@@ -69,6 +72,8 @@ func (lp *Loadpoint) SetMode(mode api.ChargeMode) {
 
 		lp.requestUpdate()
 	}
+
+	return nil
 }
 
 // getChargedEnergy returns session charge energy in Wh
@@ -161,6 +166,34 @@ func (lp *Loadpoint) SetLimitSoc(soc int) {
 	}
 }
 
+// GetSocTarget returns the default soc target
+func (lp *Loadpoint) GetSocTarget() int {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.SocTarget
+}
+
+// SetSocTarget sets the default soc target
+func (lp *Loadpoint) SetSocTarget(soc int) error {
+	if soc < 0 || soc > 100 {
+		return fmt.Errorf("invalid soc target: %d", soc)
+	}
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Println("set soc target:", soc)
+
+	// apply immediately
+	if lp.SocTarget != soc {
+		lp.SocTarget = soc
+		lp.publish(keys.SocTarget, soc)
+		lp.requestUpdate()
+	}
+
+	return nil
+}
+
 // GetLimitEnergy returns the session limit energy
 func (lp *Loadpoint) GetLimitEnergy() float64 {
 	lp.RLock()
@@ -176,7 +209,11 @@ func (lp *Loadpoint) setLimitEnergy(energy float64) {
 }
 
 // SetLimitEnergy sets the session energy limit
-func (lp *Loadpoint) SetLimitEnergy(energy float64) {
+func (lp *Loadpoint) SetLimitEnergy(energy float64) error {
+	if energy < 0 {
+		return fmt.Errorf("invalid session energy limit: %v", energy)
+	}
+
 	lp.Lock()
 	defer lp.Unlock()
 
@@ -187,6 +224,8 @@ func (lp *Loadpoint) SetLimitEnergy(energy float64) {
 		lp.setLimitEnergy(energy)
 		lp.requestUpdate()
 	}
+
+	return nil
 }
 
 // GetPlanEnergy returns plan target energy
@@ -498,3 +537,22 @@ func (lp *Loadpoint) SetSmartCostLimit(val float64) {
 		lp.publish(keys.SmartCostLimit, lp.smartCostLimit)
 	}
 }
+
+// GetLifetimeSolarEnergy returns the solar energy delivered to vehicles since installation in kWh
+func (lp *Loadpoint) GetLifetimeSolarEnergy() float64 {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.lifetimeSolarEnergy
+}
+
+// ResetLifetimeSolarEnergy resets the lifetime solar energy, e.g. for fresh installations
+func (lp *Loadpoint) ResetLifetimeSolarEnergy() {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Println("reset lifetime solar energy")
+
+	lp.lifetimeSolarEnergy = 0
+	lp.publish(keys.LifetimeSolarEnergy, lp.lifetimeSolarEnergy)
+	lp.settings.SetFloat(keys.LifetimeSolarEnergy, lp.lifetimeSolarEnergy)
+}
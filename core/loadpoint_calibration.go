@@ -0,0 +1,16 @@
+package core
+
+// calibratedPower corrects the charge meter power reading for known meter inaccuracies
+func (lp *Loadpoint) calibratedPower(power float64) float64 {
+	// keep idle readings
+	if power == 0 {
+		return 0
+	}
+
+	// scale is unset for loadpoints not created from config
+	if lp.MeterScale == 0 {
+		return power + lp.MeterOffset
+	}
+
+	return power*lp.MeterScale + lp.MeterOffset
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCalibratedPower(t *testing.T) {
+	tc := []struct {
+		offset, scale, power, expected float64
+	}{
+		{0, 1, 1000, 1000},
+		{0, 0, 1000, 1000},    // unset scale
+		{50, 1, 1000, 1050},   // positive offset
+		{-50, 1, 1000, 950},   // negative offset
+		{0, 1.05, 1000, 1050}, // positive scale correction
+		{0, 0.95, 1000, 950},  // negative scale correction
+		{-20, 1.05, 1000, 1030},
+		{50, 1.05, 0, 0}, // idle
+	}
+
+	for _, tc := range tc {
+		t.Logf("%+v", tc)
+
+		lp := &Loadpoint{MeterOffset: tc.offset, MeterScale: tc.scale}
+		assert.InDelta(t, tc.expected, lp.calibratedPower(tc.power), 1e-9)
+	}
+}
+
+func TestUpdateChargePowerCalibrated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	meter := api.NewMockMeter(ctrl)
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.chargeMeter = meter
+	lp.MeterOffset = -10
+	lp.MeterScale = 0.98
+
+	meter.EXPECT().CurrentPower().Return(1000.0, nil)
+	lp.UpdateChargePower()
+
+	assert.InDelta(t, 970.0, lp.GetChargePower(), 1e-9)
+}
+
+func TestMeterScaleValidation(t *testing.T) {
+	for _, scale := range []float64{0, -1} {
+		_, err := NewLoadpointFromConfig(util.NewLogger("foo"), nil, map[string]any{
+			"meterScale": scale,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "meter scale")
+	}
+
+	_, err := NewLoadpointFromConfig(util.NewLogger("foo"), nil, map[string]any{
+		"meterScale": 1.02,
+	})
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "meter scale")
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// ChargerCapabilities describes the optional api interfaces implemented by a charger
+type ChargerCapabilities struct {
+	HasMeter          bool `json:"hasMeter"`          // api.Meter
+	HasMeterEnergy    bool `json:"hasMeterEnergy"`    // api.MeterEnergy
+	HasMeterCurrent   bool `json:"hasMeterCurrent"`   // api.PhaseCurrents
+	HasMeterVoltage   bool `json:"hasMeterVoltage"`   // api.PhaseVoltages
+	HasPhaseSwitch    bool `json:"hasPhaseSwitch"`    // api.PhaseSwitcher
+	HasMilliAmps      bool `json:"hasMilliAmps"`      // api.ChargerEx
+	HasCurrentGetter  bool `json:"hasCurrentGetter"`  // api.CurrentGetter
+	HasCurrentLimiter bool `json:"hasCurrentLimiter"` // api.CurrentLimiter
+	HasChargeTimer    bool `json:"hasChargeTimer"`    // api.ChargeTimer
+	HasChargeRater    bool `json:"hasChargeRater"`    // api.ChargeRater
+	HasIdentifier     bool `json:"hasIdentifier"`     // api.Identifier
+	HasSoc            bool `json:"hasSoc"`            // api.Battery
+	HasWakeUp         bool `json:"hasWakeUp"`         // api.Resurrector
+	HasDiagnosis      bool `json:"hasDiagnosis"`      // api.Diagnosis
+}
+
+// probeCapabilities determines which optional api interfaces the charger implements
+func probeCapabilities(charger api.Charger) ChargerCapabilities {
+	var res ChargerCapabilities
+
+	_, res.HasMeter = charger.(api.Meter)
+	_, res.HasMeterEnergy = charger.(api.MeterEnergy)
+	_, res.HasMeterCurrent = charger.(api.PhaseCurrents)
+	_, res.HasMeterVoltage = charger.(api.PhaseVoltages)
+	_, res.HasPhaseSwitch = charger.(api.PhaseSwitcher)
+	_, res.HasMilliAmps = charger.(api.ChargerEx)
+	_, res.HasCurrentGetter = charger.(api.CurrentGetter)
+	_, res.HasCurrentLimiter = charger.(api.CurrentLimiter)
+	_, res.HasChargeTimer = charger.(api.ChargeTimer)
+	_, res.HasChargeRater = charger.(api.ChargeRater)
+	_, res.HasIdentifier = charger.(api.Identifier)
+	_, res.HasSoc = charger.(api.Battery)
+	_, res.HasWakeUp = charger.(api.Resurrector)
+	_, res.HasDiagnosis = charger.(api.Diagnosis)
+
+	return res
+}
+
+// summary returns a human-readable capability summary
+func (c ChargerCapabilities) summary() string {
+	return fmt.Sprintf("power %s energy %s currents %s voltages %s phases %s milliamps %s current %s limits %s timer %s rater %s identify %s soc %s wakeup %s diagnose %s",
+		presence[c.HasMeter],
+		presence[c.HasMeterEnergy],
+		presence[c.HasMeterCurrent],
+		presence[c.HasMeterVoltage],
+		presence[c.HasPhaseSwitch],
+		presence[c.HasMilliAmps],
+		presence[c.HasCurrentGetter],
+		presence[c.HasCurrentLimiter],
+		presence[c.HasChargeTimer],
+		presence[c.HasChargeRater],
+		presence[c.HasIdentifier],
+		presence[c.HasSoc],
+		presence[c.HasWakeUp],
+		presence[c.HasDiagnosis],
+	)
+}
+
+// publishChargerCapabilities logs and publishes the charger capabilities
+func (lp *Loadpoint) publishChargerCapabilities() {
+	res := probeCapabilities(lp.charger)
+	lp.log.INFO.Printf("charger capabilities: %s", res.summary())
+	lp.publish(keys.ChargerCapabilities, res)
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProbeCapabilities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	charger := api.NewMockCharger(ctrl)
+	assert.Equal(t, ChargerCapabilities{}, probeCapabilities(charger))
+
+	meter := &struct {
+		*api.MockCharger
+		*api.MockMeter
+		*api.MockMeterEnergy
+	}{
+		charger,
+		api.NewMockMeter(ctrl),
+		api.NewMockMeterEnergy(ctrl),
+	}
+	assert.Equal(t, ChargerCapabilities{
+		HasMeter:       true,
+		HasMeterEnergy: true,
+	}, probeCapabilities(meter))
+
+	switcher := &struct {
+		*api.MockCharger
+		*api.MockPhaseSwitcher
+		*api.MockCurrentLimiter
+		*api.MockIdentifier
+	}{
+		charger,
+		api.NewMockPhaseSwitcher(ctrl),
+		api.NewMockCurrentLimiter(ctrl),
+		api.NewMockIdentifier(ctrl),
+	}
+	assert.Equal(t, ChargerCapabilities{
+		HasPhaseSwitch:    true,
+		HasCurrentLimiter: true,
+		HasIdentifier:     true,
+	}, probeCapabilities(switcher))
+
+	rater := &struct {
+		*api.MockCharger
+		*api.MockChargeRater
+		*api.MockBattery
+	}{
+		charger,
+		api.NewMockChargeRater(ctrl),
+		api.NewMockBattery(ctrl),
+	}
+	assert.Equal(t, ChargerCapabilities{
+		HasChargeRater: true,
+		HasSoc:         true,
+	}, probeCapabilities(rater))
+}
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/planner"
+)
+
+// cheapRequiredDuration returns the charging duration required for reaching the limit soc
+func (lp *Loadpoint) cheapRequiredDuration() (time.Duration, bool) {
+	lp.RLock()
+	estimator, vehicleSoc := lp.socEstimator, lp.vehicleSoc
+	lp.RUnlock()
+
+	if estimator == nil || vehicleSoc == 0 {
+		return 0, false
+	}
+
+	return estimator.RemainingChargeDuration(lp.effectiveLimitSoc(), lp.EffectiveMaxPower()), true
+}
+
+// cheapCharging charges during the cheapest tariff windows required for reaching the limit soc by departure time.
+// Without departure time, soc or tariff rates charging starts immediately.
+func (lp *Loadpoint) cheapCharging() error {
+	var active bool
+	defer func() {
+		lp.publish(keys.CheapSlotActive, active)
+	}()
+
+	departure := lp.GetDepartureTime()
+	if departure.IsZero() || lp.planner == nil {
+		lp.log.DEBUG.Println("cheap charging: no departure time, charging now")
+		return lp.fastCharging()
+	}
+
+	requiredDuration, ok := lp.cheapRequiredDuration()
+	if !ok {
+		lp.log.DEBUG.Println("cheap charging: unknown vehicle soc, charging now")
+		return lp.fastCharging()
+	}
+
+	plan, err := lp.GetPlan(departure, requiredDuration)
+	if err != nil {
+		lp.log.WARN.Printf("cheap charging: %v, charging now", err)
+		return lp.fastCharging()
+	}
+
+	if active = !planner.SlotAt(lp.clock.Now(), plan).End.IsZero(); !active {
+		return lp.setLimit(0)
+	}
+
+	lp.log.DEBUG.Printf("cheap charging: slot active, charging %v until %v", requiredDuration.Round(time.Second), departure.Round(time.Second).Local())
+
+	return lp.fastCharging()
+}
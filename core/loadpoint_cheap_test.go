@@ -0,0 +1,73 @@
+package core
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/planner"
+	"github.com/evcc-io/evcc/core/soc"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCheapCharging(t *testing.T) {
+	Voltage = 230 // V
+
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+	vehicle := api.NewMockVehicle(ctrl)
+	tariff := api.NewMockTariff(ctrl)
+	clck := clock.NewMock()
+
+	vehicle.EXPECT().Capacity().Return(10.0).AnyTimes()
+
+	// hourly prices, cheapest in the last hours
+	prices := []float64{30, 30, 30, 30, 30, 30, 30, 30, 10, 10}
+	var rates api.Rates
+	for i, p := range prices {
+		start := clck.Now().Add(time.Duration(i) * time.Hour)
+		rates = append(rates, api.Rate{Start: start, End: start.Add(time.Hour), Price: p})
+	}
+
+	var ratesErr error
+	tariff.EXPECT().Rates().DoAndReturn(func() (api.Rates, error) {
+		return slices.Clone(rates), ratesErr
+	}).AnyTimes()
+
+	lp := newChargerTestLoadpoint(clck, charger)
+	lp.phases = 3
+	lp.vehicleSoc = 50
+	lp.planner = planner.New(util.NewLogger("foo"), tariff, planner.WithClock(clck))
+	lp.socEstimator = soc.NewEstimator(util.NewLogger("foo"), charger, vehicle, false)
+
+	charger.EXPECT().MaxCurrent(int64(16)).Return(nil).AnyTimes()
+
+	// no departure time
+	charger.EXPECT().Enable(true).Return(nil)
+	require.NoError(t, lp.cheapCharging())
+	assert.True(t, lp.enabled)
+
+	// wait for cheap slots
+	require.NoError(t, lp.SetDepartureTime(clck.Now().Add(10*time.Hour)))
+	charger.EXPECT().Enable(false).Return(nil)
+	require.NoError(t, lp.cheapCharging())
+	assert.False(t, lp.enabled)
+
+	// tariff error
+	ratesErr = errors.New("unavailable")
+	charger.EXPECT().Enable(true).Return(nil)
+	require.NoError(t, lp.cheapCharging())
+	assert.True(t, lp.enabled)
+
+	// cheap slot active
+	ratesErr = nil
+	clck.Add(9 * time.Hour)
+	require.NoError(t, lp.cheapCharging())
+	assert.True(t, lp.enabled)
+}
@@ -0,0 +1,23 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// minConnectEnergyActive returns true while the min connect energy has not been charged
+func (lp *Loadpoint) minConnectEnergyActive() bool {
+	if !lp.minConnectActive {
+		return false
+	}
+
+	remaining := max(0, lp.MinConnectEnergy-lp.getChargedEnergy()/1e3)
+	if remaining == 0 {
+		lp.log.DEBUG.Printf("min connect energy charged: %.1fkWh", lp.MinConnectEnergy)
+		lp.minConnectActive = false
+	}
+
+	lp.publish(keys.MinConnectActive, lp.minConnectActive)
+	lp.publish(keys.MinConnectRemaining, remaining)
+
+	return lp.minConnectActive
+}
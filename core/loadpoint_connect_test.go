@@ -0,0 +1,33 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinConnectEnergy(t *testing.T) {
+	lp := &Loadpoint{
+		log:              util.NewLogger("foo"),
+		sessionEnergy:    NewEnergyMetrics(),
+		MinConnectEnergy: 0.5,
+	}
+
+	// not connected yet
+	assert.False(t, lp.minConnectEnergyActive())
+
+	lp.minConnectActive = true
+	assert.True(t, lp.minConnectEnergyActive())
+
+	lp.sessionEnergy.Update(0.4)
+	assert.True(t, lp.minConnectEnergyActive())
+
+	// minimum met
+	lp.sessionEnergy.Update(0.5)
+	assert.False(t, lp.minConnectEnergyActive())
+	assert.False(t, lp.minConnectActive)
+
+	// remains cleared for the session
+	assert.False(t, lp.minConnectEnergyActive())
+}
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/session"
+)
+
+// connectStats are average session durations in minutes
+type connectStats struct {
+	Connected   float64 // average connected duration
+	Charging    float64 // average charging duration
+	Idle        float64 // average connected duration without charging
+	Utilisation float64 // charging share of connected duration in %
+}
+
+// connectDurationStats calculates average durations of disconnected sessions
+func connectDurationStats(sessions session.Sessions) connectStats {
+	var res connectStats
+	var count int
+
+	for _, s := range sessions {
+		if s.Connected.IsZero() || !s.Disconnected.After(s.Connected) {
+			continue
+		}
+
+		connected := s.Disconnected.Sub(s.Connected).Minutes()
+
+		var charging float64
+		if s.ChargeDuration != nil {
+			charging = min(s.ChargeDuration.Minutes(), connected)
+		}
+
+		res.Connected += connected
+		res.Charging += charging
+		count++
+	}
+
+	if count == 0 {
+		return res
+	}
+
+	res.Connected /= float64(count)
+	res.Charging /= float64(count)
+	res.Idle = res.Connected - res.Charging
+	res.Utilisation = 100 * res.Charging / res.Connected
+
+	return res
+}
+
+// publishConnectDurationStats publishes connect duration statistics from the session history
+func (lp *Loadpoint) publishConnectDurationStats() {
+	// test guard
+	if lp.db == nil {
+		return
+	}
+
+	sessions, err := lp.db.LoadpointSessions()
+	if err != nil {
+		lp.log.ERROR.Printf("connect duration stats: %v", err)
+		return
+	}
+
+	res := connectDurationStats(sessions)
+
+	lp.publish(keys.AvgConnectedDuration, res.Connected)
+	lp.publish(keys.AvgChargingDuration, res.Charging)
+	lp.publish(keys.AvgIdleDuration, res.Idle)
+	lp.publish(keys.ChargingUtilisation, res.Utilisation)
+}
@@ -0,0 +1,38 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectDurationStats(t *testing.T) {
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	record := func(connected, charging time.Duration) session.Session {
+		return session.Session{
+			Connected:      start,
+			Created:        start.Add(time.Minute),
+			Finished:       start.Add(charging),
+			Disconnected:   start.Add(connected),
+			ChargeDuration: &charging,
+		}
+	}
+
+	assert.Equal(t, connectStats{}, connectDurationStats(nil))
+
+	res := connectDurationStats(session.Sessions{
+		record(2*time.Hour, time.Hour),
+		record(4*time.Hour, 2*time.Hour),
+		record(6*time.Hour, 6*time.Hour),
+		{Connected: start},                               // connected
+		{Created: start, Finished: start.Add(time.Hour)}, // unknown connect time
+	})
+
+	assert.InDelta(t, 240, res.Connected, 1e-6)
+	assert.InDelta(t, 180, res.Charging, 1e-6)
+	assert.InDelta(t, 60, res.Idle, 1e-6)
+	assert.InDelta(t, 75, res.Utilisation, 1e-6)
+}
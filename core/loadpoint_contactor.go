@@ -0,0 +1,25 @@
+package core
+
+import "github.com/evcc-io/evcc/core/keys"
+
+// setContactorWearWarning updates the contactor maintenance warning state
+func (lp *Loadpoint) setContactorWearWarning(warn bool) {
+	lp.contactorWearWarning = warn
+	lp.publish(keys.ContactorWearWarning, warn)
+}
+
+// recordContactorOp counts a charger enable/disable transition and sends a maintenance notification each time the warning interval is reached
+func (lp *Loadpoint) recordContactorOp() {
+	lp.contactorOps++
+	lp.publish(keys.ContactorOps, lp.contactorOps)
+	lp.settings.SetInt(keys.ContactorOps, int64(lp.contactorOps))
+
+	if lp.ContactorWarnAt <= 0 || lp.contactorOps%lp.ContactorWarnAt != 0 {
+		return
+	}
+
+	lp.log.WARN.Printf("contactor operations: %d, check charger contactor for wear", lp.contactorOps)
+
+	lp.setContactorWearWarning(true)
+	lp.pushEvent(evContactorWear)
+}
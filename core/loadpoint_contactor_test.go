@@ -0,0 +1,70 @@
+package core
+
+import (
+	"testing"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestContactorWearWarning(t *testing.T) {
+	pushChan := make(chan push.Event, 10)
+
+	lp := &Loadpoint{
+		log:             util.NewLogger("foo"),
+		pushChan:        pushChan,
+		ContactorWarnAt: 3,
+	}
+
+	var fired []int
+	for i := 0; i < 7; i++ {
+		lp.recordContactorOp()
+
+		select {
+		case ev := <-pushChan:
+			assert.Equal(t, evContactorWear, ev.Event)
+			fired = append(fired, lp.contactorOps)
+		default:
+		}
+	}
+
+	assert.Equal(t, 7, lp.contactorOps)
+	assert.Equal(t, []int{3, 6}, fired)
+	assert.True(t, lp.contactorWearWarning)
+}
+
+func TestContactorOpsOnEnable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+
+	lp := &Loadpoint{
+		log:             util.NewLogger("foo"),
+		bus:             evbus.New(),
+		clock:           clock.NewMock(),
+		charger:         charger,
+		wakeUpTimer:     NewTimer(),
+		minCurrent:      6,
+		maxCurrent:      16,
+		ContactorWarnAt: 5000,
+	}
+
+	charger.EXPECT().MaxCurrent(int64(6)).Return(nil)
+	charger.EXPECT().Enable(true).Return(nil)
+	require.NoError(t, lp.setLimit(6))
+	assert.Equal(t, 1, lp.contactorOps)
+
+	// no transition
+	require.NoError(t, lp.setLimit(6))
+	assert.Equal(t, 1, lp.contactorOps)
+
+	charger.EXPECT().Enable(false).Return(nil)
+	require.NoError(t, lp.setLimit(0))
+	assert.Equal(t, 2, lp.contactorOps)
+	assert.False(t, lp.contactorWearWarning)
+}
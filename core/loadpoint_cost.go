@@ -0,0 +1,26 @@
+package core
+
+import "github.com/evcc-io/evcc/core/keys"
+
+// setCostAlertFired updates the session cost alert state
+func (lp *Loadpoint) setCostAlertFired(fired bool) {
+	lp.costAlertFired = fired
+	lp.publish(keys.CostAlertFired, fired)
+}
+
+// checkCostAlert sends a notification once per session when the session cost exceeds the alert threshold
+func (lp *Loadpoint) checkCostAlert() {
+	if lp.CostAlertThreshold <= 0 || lp.costAlertFired {
+		return
+	}
+
+	price := lp.sessionEnergy.Price()
+	if price == nil || *price <= lp.CostAlertThreshold {
+		return
+	}
+
+	lp.log.INFO.Printf("session cost %.2f exceeds alert threshold %.2f", *price, lp.CostAlertThreshold)
+
+	lp.setCostAlertFired(true)
+	lp.pushEvent(evCostAlert)
+}
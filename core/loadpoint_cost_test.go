@@ -0,0 +1,49 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCostAlert(t *testing.T) {
+	pushChan := make(chan push.Event, 1)
+
+	lp := &Loadpoint{
+		log:                util.NewLogger("foo"),
+		pushChan:           pushChan,
+		sessionEnergy:      NewEnergyMetrics(),
+		CostAlertThreshold: 2,
+	}
+
+	price := 0.4
+	lp.sessionEnergy.SetEnvironment(0, &price, nil)
+
+	var fired []float64
+	for _, kWh := range []float64{1, 3, 5, 6, 8, 10} {
+		lp.sessionEnergy.Update(kWh)
+		lp.checkCostAlert()
+
+		select {
+		case ev := <-pushChan:
+			assert.Equal(t, evCostAlert, ev.Event)
+			fired = append(fired, *lp.sessionEnergy.Price())
+		default:
+		}
+	}
+
+	// 6kWh @ 0.40 = 2.40
+	assert.Len(t, fired, 1)
+	assert.InDelta(t, 2.4, fired[0], 1e-6)
+	assert.True(t, lp.costAlertFired)
+
+	// new session
+	lp.sessionEnergy.Reset()
+	lp.setCostAlertFired(false)
+
+	lp.sessionEnergy.Update(10)
+	lp.checkCostAlert()
+	assert.Len(t, pushChan, 1)
+}
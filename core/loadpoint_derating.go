@@ -0,0 +1,60 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// updateChargerTemperature reads the charger temperature used for max current derating
+func (lp *Loadpoint) updateChargerTemperature() {
+	if !lp.Derating.Enable {
+		return
+	}
+
+	c, ok := lp.charger.(api.Temperature)
+	if !ok {
+		return
+	}
+
+	temp, err := c.Temperature()
+	if err != nil {
+		lp.log.ERROR.Printf("charger temperature: %v", err)
+		return
+	}
+
+	lp.Lock()
+	lp.chargerTemperature = temp
+	lp.Unlock()
+
+	maxCurrent := lp.GetMaxCurrent()
+	res := lp.deratedMaxCurrent(maxCurrent)
+
+	lp.publish(keys.ChargerTemperature, temp)
+	lp.publish(keys.DeratingActive, res < maxCurrent)
+	lp.publish(keys.DeratedMaxCurrent, int64(res))
+}
+
+// deratedMaxCurrent applies temperature derating to max current
+func (lp *Loadpoint) deratedMaxCurrent(maxCurrent float64) float64 {
+	if !lp.Derating.Enable {
+		return maxCurrent
+	}
+
+	lp.RLock()
+	temp := lp.chargerTemperature
+	lp.RUnlock()
+
+	return deratedCurrent(maxCurrent, temp, lp.Derating.StartTemp, lp.Derating.EndTemp)
+}
+
+// deratedCurrent reduces current linearly from start to end temperature
+func deratedCurrent(current, temp, startTemp, endTemp float64) float64 {
+	switch {
+	case temp <= startTemp:
+		return current
+	case temp >= endTemp:
+		return 0
+	default:
+		return current * (1 - (temp-startTemp)/(endTemp-startTemp))
+	}
+}
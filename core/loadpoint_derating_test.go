@@ -0,0 +1,82 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// temperatureStub adds temperature readings to a charger mock
+type temperatureStub struct {
+	*api.MockCharger
+	temp float64
+}
+
+func (c *temperatureStub) Temperature() (float64, error) {
+	return c.temp, nil
+}
+
+func TestDeratedCurrent(t *testing.T) {
+	for _, tc := range []struct {
+		temp, expected float64
+	}{
+		{20, 16},
+		{35, 16},
+		{40, 12},
+		{45, 8},
+		{50, 4},
+		{55, 0},
+		{60, 0},
+	} {
+		assert.InDelta(t, tc.expected, deratedCurrent(16, tc.temp, 35, 55), 1e-9, tc.temp)
+	}
+}
+
+func TestEffectiveMaxCurrentDerating(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := &temperatureStub{MockCharger: api.NewMockCharger(ctrl)}
+	uiChan := make(chan util.Param, 16)
+
+	lp := &Loadpoint{
+		log:        util.NewLogger("foo"),
+		uiChan:     uiChan,
+		charger:    charger,
+		maxCurrent: 16,
+		Derating:   DeratingConfig{StartTemp: 35, EndTemp: 55},
+	}
+
+	// disabled
+	charger.temp = 45
+	lp.updateChargerTemperature()
+	assert.Equal(t, 16.0, lp.effectiveMaxCurrent())
+
+	lp.Derating.Enable = true
+
+	for _, tc := range []struct {
+		temp, expected float64
+	}{
+		{30, 16},
+		{45, 8},
+		{55, 0},
+		{35, 16},
+	} {
+		charger.temp = tc.temp
+		lp.updateChargerTemperature()
+
+		published := make(map[string]any)
+		for len(uiChan) > 0 {
+			p := <-uiChan
+			published[p.Key] = p.Val
+		}
+		assert.Equal(t, int64(tc.expected), published[keys.DeratedMaxCurrent], tc.temp)
+		assert.Equal(t, tc.expected < 16, published[keys.DeratingActive], tc.temp)
+
+		// effective max current does not publish
+		assert.InDelta(t, tc.expected, lp.effectiveMaxCurrent(), 1e-9, tc.temp)
+		assert.Empty(t, uiChan)
+	}
+}
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+const (
+	maxCurrentDetectStep     = 2.0              // A
+	maxCurrentDetectInterval = 30 * time.Second // step holding time
+)
+
+// detectedMaxCurrentKey returns the settings key of the detected max current by charger
+func (lp *Loadpoint) detectedMaxCurrentKey() string {
+	return keys.DetectedMaxCurrent + "." + lp.ChargerRef
+}
+
+// startMaxCurrentDetection starts max current detection unless the charger's max current is already known
+func (lp *Loadpoint) startMaxCurrentDetection() {
+	lp.detectActive = lp.AutoMaxCurrentDetect && lp.detectedMaxCurrent == 0
+	lp.detectStepped = time.Time{}
+	lp.detectStable = 0
+	lp.detectCharging = false
+}
+
+// stopMaxCurrentDetection ends max current detection and stores the result
+func (lp *Loadpoint) stopMaxCurrentDetection(current float64) {
+	lp.detectActive = false
+	lp.publish(keys.MaxCurrentDetectActive, false)
+
+	if current == 0 {
+		lp.log.WARN.Println("max current detection: no stable charge current, retrying on next connect")
+		return
+	}
+
+	// keep a higher value confirmed before
+	if current <= lp.detectedMaxCurrent {
+		return
+	}
+
+	lp.log.INFO.Printf("max current detection: detected %.3gA", current)
+
+	lp.detectedMaxCurrent = current
+	lp.publish(keys.DetectedMaxCurrent, current)
+	lp.settings.SetFloat(lp.detectedMaxCurrentKey(), current)
+
+	if v := lp.GetVehicle(); v != nil {
+		lp.detectedVehicle = v.Title()
+	}
+	lp.settings.SetString(lp.detectedMaxCurrentKey()+".vehicle", lp.detectedVehicle)
+}
+
+// resetMaxCurrentDetection discards the detected max current if it was measured with a different vehicle
+func (lp *Loadpoint) resetMaxCurrentDetection(v api.Vehicle) {
+	if lp.detectedMaxCurrent == 0 || v == nil || v.Title() == lp.detectedVehicle {
+		return
+	}
+
+	lp.log.DEBUG.Printf("max current detection: vehicle changed, discarding %.3gA", lp.detectedMaxCurrent)
+
+	lp.detectedMaxCurrent = 0
+	lp.detectedVehicle = ""
+	lp.publish(keys.DetectedMaxCurrent, 0.0)
+	lp.settings.SetFloat(lp.detectedMaxCurrentKey(), 0)
+	lp.settings.SetString(lp.detectedMaxCurrentKey()+".vehicle", "")
+
+	if lp.connected() {
+		lp.startMaxCurrentDetection()
+	}
+}
+
+// maxCurrentDetectActive ramps up the charge current in steps while the vehicle keeps charging.
+// Returns true while detection is ongoing and detectCurrent should be applied.
+func (lp *Loadpoint) maxCurrentDetectActive() bool {
+	if !lp.detectActive {
+		return false
+	}
+
+	// first step
+	if lp.detectStepped.IsZero() {
+		lp.detectCurrent = lp.effectiveMinCurrent()
+		lp.detectStepped = lp.clock.Now()
+		lp.publish(keys.MaxCurrentDetectActive, true)
+		return true
+	}
+
+	// a step is only confirmed if charging continues throughout the step
+	charging := lp.status == api.StatusC
+	if !charging && lp.detectCharging {
+		lp.log.DEBUG.Printf("max current detection: charging stopped at %.3gA", lp.detectCurrent)
+		lp.stopMaxCurrentDetection(lp.detectStable)
+		return false
+	}
+	lp.detectCharging = charging
+
+	if lp.clock.Since(lp.detectStepped) < maxCurrentDetectInterval {
+		return true
+	}
+
+	// charger fault or vehicle rejected the current
+	if !charging {
+		lp.log.DEBUG.Printf("max current detection: not charging at %.3gA", lp.detectCurrent)
+		lp.stopMaxCurrentDetection(lp.detectStable)
+		return false
+	}
+
+	lp.detectStable = lp.detectCurrent
+
+	// try configured max current as last step
+	next := min(lp.detectCurrent+maxCurrentDetectStep, lp.GetMaxCurrent())
+	if next <= lp.detectCurrent {
+		lp.stopMaxCurrentDetection(lp.detectStable)
+		return false
+	}
+
+	lp.log.DEBUG.Printf("max current detection: %.3gA stable, trying %.3gA", lp.detectStable, next)
+	lp.detectCurrent = next
+	lp.detectStepped = lp.clock.Now()
+
+	return true
+}
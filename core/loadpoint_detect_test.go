@@ -0,0 +1,142 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMaxCurrentDetection(t *testing.T) {
+	clck := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:                  util.NewLogger("foo"),
+		clock:                clck,
+		minCurrent:           6,
+		maxCurrent:           32,
+		AutoMaxCurrentDetect: true,
+	}
+
+	// charger faults at 14A
+	charge := func() {
+		lp.status = api.StatusC
+		if lp.detectCurrent >= 14 {
+			lp.status = api.StatusB
+		}
+	}
+
+	lp.startMaxCurrentDetection()
+
+	var steps []float64
+	for lp.maxCurrentDetectActive() {
+		if len(steps) == 0 || steps[len(steps)-1] != lp.detectCurrent {
+			steps = append(steps, lp.detectCurrent)
+		}
+		charge()
+		clck.Add(maxCurrentDetectInterval / 2)
+	}
+
+	assert.Equal(t, []float64{6, 8, 10, 12, 14}, steps)
+	assert.False(t, lp.detectActive)
+	assert.Equal(t, 12.0, lp.detectedMaxCurrent)
+	assert.Equal(t, 12.0, lp.effectiveMaxCurrent())
+
+	// subsequent connects use detected value
+	lp.startMaxCurrentDetection()
+	assert.False(t, lp.maxCurrentDetectActive())
+	assert.Equal(t, 12.0, lp.effectiveMaxCurrent())
+}
+
+func TestMaxCurrentDetectionLimits(t *testing.T) {
+	clck := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:                  util.NewLogger("foo"),
+		clock:                clck,
+		minCurrent:           6,
+		maxCurrent:           10,
+		AutoMaxCurrentDetect: true,
+	}
+
+	// not charging at min current, no result
+	lp.startMaxCurrentDetection()
+	lp.status = api.StatusB
+	assert.True(t, lp.maxCurrentDetectActive())
+	clck.Add(maxCurrentDetectInterval)
+	assert.False(t, lp.maxCurrentDetectActive())
+	assert.Equal(t, 0.0, lp.detectedMaxCurrent)
+
+	// ramp stops at configured max current
+	lp.startMaxCurrentDetection()
+	lp.status = api.StatusC
+	for lp.maxCurrentDetectActive() {
+		clck.Add(time.Minute)
+	}
+	assert.Equal(t, 10.0, lp.detectedMaxCurrent)
+
+	// configured max current not reached by full steps is tried last
+	lp.maxCurrent = 15
+	lp.detectedMaxCurrent = 0
+	lp.startMaxCurrentDetection()
+
+	var steps []float64
+	for lp.maxCurrentDetectActive() {
+		if len(steps) == 0 || steps[len(steps)-1] != lp.detectCurrent {
+			steps = append(steps, lp.detectCurrent)
+		}
+		clck.Add(time.Minute)
+	}
+	assert.Equal(t, []float64{6, 8, 10, 12, 14, 15}, steps)
+	assert.Equal(t, 15.0, lp.detectedMaxCurrent)
+}
+
+func TestMaxCurrentDetectionConfirmed(t *testing.T) {
+	clck := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	lp := &Loadpoint{
+		log:                  util.NewLogger("foo"),
+		clock:                clck,
+		minCurrent:           6,
+		maxCurrent:           16,
+		AutoMaxCurrentDetect: true,
+	}
+
+	// charging interrupted during step, last confirmed step is kept
+	lp.startMaxCurrentDetection()
+	lp.status = api.StatusC
+	for lp.detectCurrent < 10 {
+		assert.True(t, lp.maxCurrentDetectActive())
+		clck.Add(maxCurrentDetectInterval)
+	}
+	lp.status = api.StatusB
+	assert.False(t, lp.maxCurrentDetectActive())
+	assert.Equal(t, 8.0, lp.detectedMaxCurrent)
+
+	// lower result does not overwrite
+	lp.stopMaxCurrentDetection(6)
+	assert.Equal(t, 8.0, lp.detectedMaxCurrent)
+
+	// higher result overwrites
+	lp.stopMaxCurrentDetection(12)
+	assert.Equal(t, 12.0, lp.detectedMaxCurrent)
+
+	// vehicle change discards result and restarts detection
+	vehicle := api.NewMockVehicle(ctrl)
+	vehicle.EXPECT().Title().Return("other").AnyTimes()
+
+	lp.resetMaxCurrentDetection(vehicle)
+	assert.Equal(t, 0.0, lp.detectedMaxCurrent)
+	assert.True(t, lp.detectActive)
+
+	// same vehicle keeps result
+	lp.detectedMaxCurrent = 12
+	lp.detectedVehicle = "other"
+	lp.resetMaxCurrentDetection(vehicle)
+	assert.Equal(t, 12.0, lp.detectedMaxCurrent)
+}
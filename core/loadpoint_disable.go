@@ -0,0 +1,21 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// effectiveDisableDelay returns the pv disable delay, extended if the vehicle soc is critically low
+func (lp *Loadpoint) effectiveDisableDelay() time.Duration {
+	cfg := lp.DisableExtension
+	extended := cfg.Enable && cfg.Factor > 1 && lp.vehicleSoc > 0 && lp.vehicleSoc < cfg.BelowSoc
+
+	lp.publish(keys.DisableTimerExtended, extended)
+
+	if !extended {
+		return lp.Disable.Delay
+	}
+
+	return time.Duration(float64(lp.Disable.Delay) * cfg.Factor)
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEffectiveDisableDelay(t *testing.T) {
+	lp := &Loadpoint{
+		log:              util.NewLogger("foo"),
+		Disable:          ThresholdConfig{Delay: time.Minute},
+		DisableExtension: DisableExtensionConfig{BelowSoc: 20, Factor: 2},
+	}
+
+	tc := []struct {
+		enable bool
+		soc    float64
+		delay  time.Duration
+	}{
+		{false, 10, time.Minute},
+		{true, 0, time.Minute}, // unknown soc
+		{true, 10, 2 * time.Minute},
+		{true, 20, time.Minute},
+		{true, 50, time.Minute},
+	}
+
+	for _, tc := range tc {
+		t.Logf("%+v", tc)
+		lp.DisableExtension.Enable = tc.enable
+		lp.vehicleSoc = tc.soc
+		assert.Equal(t, tc.delay, lp.effectiveDisableDelay())
+	}
+}
+
+func TestPvDisableExtension(t *testing.T) {
+	Voltage = 230 // V
+
+	clck := clock.NewMock()
+	lp := &Loadpoint{
+		log:              util.NewLogger("foo"),
+		clock:            clck,
+		charger:          api.NewMockCharger(gomock.NewController(t)),
+		status:           api.StatusC,
+		enabled:          true,
+		phases:           1,
+		minCurrent:       minA,
+		maxCurrent:       maxA,
+		chargeCurrent:    minA,
+		vehicleSoc:       15,
+		Disable:          ThresholdConfig{Delay: time.Minute},
+		DisableExtension: DisableExtensionConfig{Enable: true, BelowSoc: 20, Factor: 2},
+	}
+
+	// cloud shadow
+	sitePower := 1000.0
+
+	assert.Equal(t, float64(minA), lp.pvMaxCurrent(api.ModePV, sitePower, false, false))
+
+	// normal disable delay elapsed, deferred at low soc
+	clck.Add(time.Minute)
+	assert.Equal(t, float64(minA), lp.pvMaxCurrent(api.ModePV, sitePower, false, false))
+
+	// extended delay elapsed
+	clck.Add(time.Minute)
+	assert.Equal(t, 0.0, lp.pvMaxCurrent(api.ModePV, sitePower, false, false))
+}
@@ -0,0 +1,20 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// maxSessionDurationReached returns true if the session charge duration has exceeded the configured maximum
+func (lp *Loadpoint) maxSessionDurationReached() bool {
+	if lp.MaxSessionDuration <= 0 {
+		return false
+	}
+
+	remaining := max(lp.MaxSessionDuration-lp.chargeDuration, 0)
+	res := remaining == 0
+
+	lp.publish(keys.SessionTimeRemaining, remaining)
+	lp.publish(keys.MaxSessionDurationActive, res)
+
+	return res
+}
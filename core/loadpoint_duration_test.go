@@ -0,0 +1,43 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMaxSessionDuration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	timer := api.NewMockChargeTimer(ctrl)
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		chargeMeter:   &Null{},
+		chargeRater:   &Null{},
+		chargeTimer:   timer,
+		sessionEnergy: NewEnergyMetrics(),
+	}
+
+	assert.False(t, lp.maxSessionDurationReached(), "unlimited")
+
+	lp.MaxSessionDuration = time.Hour
+
+	for _, tc := range []struct {
+		duration time.Duration
+		reached  bool
+	}{
+		{0, false},
+		{59*time.Minute + 59*time.Second, false},
+		{time.Hour, true},
+		{2 * time.Hour, true},
+	} {
+		timer.EXPECT().ChargingTime().Return(tc.duration, nil)
+		lp.publishChargeProgress()
+
+		assert.Equal(t, tc.reached, lp.maxSessionDurationReached(), tc.duration)
+	}
+}
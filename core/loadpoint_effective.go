@@ -28,8 +28,11 @@ func (lp *Loadpoint) EffectivePriority() int {
 	return lp.GetPriority()
 }
 
-// vehiclePlanSoc returns the next vehicle plan time and soc
+// vehiclePlanSoc returns the next vehicle plan time and soc, preferring the one-time schedule (no mutex)
 func (lp *Loadpoint) vehiclePlanSoc() (time.Time, int) {
+	if !lp.oneTimeDeparture.IsZero() {
+		return lp.oneTimeDeparture, lp.oneTimeSoc
+	}
 	if v := lp.GetVehicle(); v != nil {
 		return vehicle.Settings(lp.log, v).GetPlanSoc()
 	}
@@ -38,19 +41,24 @@ func (lp *Loadpoint) vehiclePlanSoc() (time.Time, int) {
 
 // EffectivePlanSoc returns the soc target for the current plan
 func (lp *Loadpoint) EffectivePlanSoc() int {
+	lp.RLock()
+	defer lp.RUnlock()
+
 	_, soc := lp.vehiclePlanSoc()
 	return soc
 }
 
 // EffectivePlanTime returns the effective plan time
 func (lp *Loadpoint) EffectivePlanTime() time.Time {
+	lp.RLock()
+	defer lp.RUnlock()
+
 	if lp.socBasedPlanning() {
 		ts, _ := lp.vehiclePlanSoc()
 		return ts
 	}
 
-	ts, _ := lp.GetPlanEnergy()
-	return ts
+	return lp.planTime
 }
 
 // SocBasedPlanning returns true if soc based planning is enabled
@@ -60,7 +68,7 @@ func (lp *Loadpoint) SocBasedPlanning() bool {
 
 // effectiveMinCurrent returns the effective min current
 func (lp *Loadpoint) effectiveMinCurrent() float64 {
-	lpMin := lp.GetMinCurrent()
+	lpMin, _ := lp.profileCurrents()
 	var vehicleMin, chargerMin float64
 
 	if v := lp.GetVehicle(); v != nil {
@@ -75,19 +83,46 @@ func (lp *Loadpoint) effectiveMinCurrent() float64 {
 		}
 	}
 
+	var res float64
 	switch {
 	case max(vehicleMin, chargerMin) == 0:
-		return lpMin
+		res = lpMin
 	case chargerMin > 0:
-		return max(vehicleMin, chargerMin)
+		res = max(vehicleMin, chargerMin)
 	default:
-		return max(vehicleMin, lpMin)
+		res = max(vehicleMin, lpMin)
 	}
+
+	// raise minpv floor during night rate
+	if lp.NightMinCurrent > 0 && lp.GetMode() == api.ModeMinPV && lp.nightRateActive() {
+		res = max(res, min(float64(lp.NightMinCurrent), lp.GetMaxCurrent()))
+	}
+
+	return res
 }
 
 // effectiveMaxCurrent returns the effective max current
 func (lp *Loadpoint) effectiveMaxCurrent() float64 {
-	maxCurrent := lp.GetMaxCurrent()
+	maxCurrent := lp.unallocatedMaxCurrent()
+
+	if allocated, ok := lp.getAllocatedCurrent(); ok {
+		maxCurrent = min(maxCurrent, allocated)
+	}
+
+	if power, ok := lp.getAllocatedPower(); ok {
+		maxCurrent = min(maxCurrent, lp.powerToCurrent(power, lp.ActivePhases()))
+	}
+
+	if lp.SocRamp && lp.vehicleSoc > 0 {
+		maxCurrent = min(maxCurrent, socRampCurrent(lp.effectiveMinCurrent(), maxCurrent, lp.vehicleSoc, lp.effectiveLimitSoc()))
+	}
+
+	return maxCurrent
+}
+
+// unallocatedMaxCurrent returns the effective max current before site fuse and grid power allocation
+func (lp *Loadpoint) unallocatedMaxCurrent() float64 {
+	_, maxCurrent := lp.profileCurrents()
 
 	if v := lp.GetVehicle(); v != nil {
 		if res, ok := v.OnIdentified().GetMaxCurrent(); ok && res > 0 {
@@ -95,13 +130,39 @@ func (lp *Loadpoint) effectiveMaxCurrent() float64 {
 		}
 	}
 
+	if limit := lp.getVehicleCurrentLimit(); limit > 0 {
+		maxCurrent = min(maxCurrent, float64(limit))
+	}
+
 	if c, ok := lp.charger.(api.CurrentLimiter); ok {
 		if _, res, err := c.GetMinMaxCurrent(); err == nil && res > 0 {
 			maxCurrent = min(maxCurrent, res)
 		}
 	}
 
-	return maxCurrent
+	if lp.detectedMaxCurrent > 0 {
+		maxCurrent = min(maxCurrent, lp.detectedMaxCurrent)
+	}
+
+	return lp.deratedMaxCurrent(maxCurrent)
+}
+
+// socRampCurrent scales the current between min and max depending on the distance of soc from limit soc
+func socRampCurrent(minCurrent, maxCurrent, soc float64, limitSoc int) float64 {
+	if limitSoc <= 0 || soc >= float64(limitSoc) {
+		return minCurrent
+	}
+
+	return minCurrent + (maxCurrent-minCurrent)*(1-soc/float64(limitSoc))
+}
+
+// minPVSocScaledCurrent scales the min current down to zero as soc approaches limit soc
+func minPVSocScaledCurrent(minCurrent, soc float64, limitSoc int) float64 {
+	if limitSoc <= 0 {
+		limitSoc = 100
+	}
+
+	return max(0, minCurrent*(1-soc/float64(limitSoc)))
 }
 
 // effectiveLimitSoc returns the effective session limit soc
@@ -120,17 +181,67 @@ func (lp *Loadpoint) effectiveLimitSoc() int {
 		}
 	}
 
+	if lp.SocTarget > 0 {
+		return lp.SocTarget
+	}
+
 	// MUST return 100 here as UI looks at effectiveLimitSoc and not limitSoc (VehicleSoc.vue)
 	return 100
 }
 
+// effectiveVoltage returns the loadpoint's nominal voltage, falling back to the site and nominal voltage
+func (lp *Loadpoint) effectiveVoltage() float64 {
+	if lp.Voltage > 0 {
+		return lp.Voltage
+	}
+	if Voltage > 0 {
+		return Voltage
+	}
+	return nominalVoltage
+}
+
+// powerToCurrent converts power to per-phase current
+func (lp *Loadpoint) powerToCurrent(power float64, phases int) float64 {
+	return power / (float64(phases) * lp.effectiveVoltage())
+}
+
 // EffectiveMinPower returns the effective min power for a single phase
 func (lp *Loadpoint) EffectiveMinPower() float64 {
 	// TODO check if 1p available
-	return Voltage * lp.effectiveMinCurrent()
+	return lp.effectiveVoltage() * lp.effectiveMinCurrent()
 }
 
 // EffectiveMaxPower returns the effective max power taking vehicle capabilities and phase scaling into account
 func (lp *Loadpoint) EffectiveMaxPower() float64 {
-	return Voltage * lp.effectiveMaxCurrent() * float64(lp.maxActivePhases())
+	return lp.effectiveVoltage() * lp.effectiveMaxCurrent() * float64(lp.maxActivePhases())
+}
+
+// getAllocatedCurrent returns the site fuse current allocation and if it is active
+func (lp *Loadpoint) getAllocatedCurrent() (float64, bool) {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.allocatedCurrent, lp.currentAllocated
+}
+
+// setAllocatedCurrent sets the site fuse current allocation
+func (lp *Loadpoint) setAllocatedCurrent(current float64, active bool) {
+	lp.Lock()
+	defer lp.Unlock()
+	lp.allocatedCurrent = current
+	lp.currentAllocated = active
+}
+
+// getAllocatedPower returns the site grid power allocation and if it is active
+func (lp *Loadpoint) getAllocatedPower() (float64, bool) {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.allocatedPower, lp.powerAllocated
+}
+
+// setAllocatedPower sets the site grid power allocation
+func (lp *Loadpoint) setAllocatedPower(power float64, active bool) {
+	lp.Lock()
+	defer lp.Unlock()
+	lp.allocatedPower = power
+	lp.powerAllocated = active
 }
@@ -14,6 +14,29 @@ func TestEffectiveLimitSoc(t *testing.T) {
 	assert.Equal(t, 100, lp.effectiveLimitSoc())
 }
 
+func TestEffectiveLimitSocTarget(t *testing.T) {
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.SocTarget = 80
+
+	// configured soc target
+	assert.Equal(t, 80, lp.effectiveLimitSoc())
+
+	lp.vehicleSoc = 79
+	assert.False(t, lp.limitSocReached())
+	lp.vehicleSoc = 80
+	assert.True(t, lp.limitSocReached())
+
+	// resume when soc drops below target
+	lp.vehicleSoc = 78
+	assert.False(t, lp.limitSocReached())
+
+	// session limit takes precedence
+	lp.limitSoc = 90
+	assert.Equal(t, 90, lp.effectiveLimitSoc())
+	lp.vehicleSoc = 85
+	assert.False(t, lp.limitSocReached())
+}
+
 func TestEffectiveMinMaxCurrent(t *testing.T) {
 	tc := []struct {
 		chargerMin, chargerMax     float64
@@ -68,3 +91,142 @@ func TestEffectiveMinMaxCurrent(t *testing.T) {
 		assert.Equal(t, tc.effectiveMax, lp.effectiveMaxCurrent(), "max")
 	}
 }
+
+func TestSocRampCurrent(t *testing.T) {
+	tc := []struct {
+		soc      float64
+		limitSoc int
+		current  float64
+	}{
+		{0, 100, 16},
+		{20, 100, 14},
+		{50, 100, 11},
+		{90, 100, 7},
+		{100, 100, 6},
+		{40, 80, 11},
+		{90, 80, 6}, // above limit
+		{50, 0, 6},  // no limit
+	}
+
+	for _, tc := range tc {
+		t.Logf("%+v", tc)
+		assert.InDelta(t, tc.current, socRampCurrent(6, 16, tc.soc, tc.limitSoc), 1e-6)
+	}
+}
+
+func TestEffectiveMaxCurrentSocRamp(t *testing.T) {
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.charger = api.NewMockCharger(gomock.NewController(t))
+	lp.vehicleSoc = 50
+
+	assert.Equal(t, 16.0, lp.effectiveMaxCurrent(), "disabled")
+
+	lp.SocRamp = true
+	assert.Equal(t, 11.0, lp.effectiveMaxCurrent(), "enabled")
+
+	lp.vehicleSoc = 0
+	assert.Equal(t, 16.0, lp.effectiveMaxCurrent(), "unknown soc")
+}
+
+func TestMinPVSocScaledCurrent(t *testing.T) {
+	tc := []struct {
+		soc      float64
+		limitSoc int
+		current  float64
+	}{
+		{0, 100, 6},
+		{25, 100, 4.5},
+		{50, 100, 3},
+		{100, 100, 0},
+		{40, 80, 3},
+		{60, 80, 1.5},
+		{90, 80, 0}, // above limit
+		{50, 0, 3},  // no limit
+	}
+
+	for _, tc := range tc {
+		t.Logf("%+v", tc)
+		assert.InDelta(t, tc.current, minPVSocScaledCurrent(6, tc.soc, tc.limitSoc), 1e-6)
+	}
+}
+
+func TestMinPVSocScaling(t *testing.T) {
+	Voltage = 230 // V
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.charger = api.NewMockCharger(gomock.NewController(t))
+	lp.status = api.StatusC
+	lp.enabled = true
+	lp.phases = 1
+	lp.chargeCurrent = 6
+	lp.limitSoc = 80
+	lp.Disable = ThresholdConfig{}
+
+	// pv covers 3A of 6A min current
+	sitePower := 3 * Voltage
+
+	tc := []struct {
+		scaling bool
+		soc     float64
+		current float64
+	}{
+		{false, 70, 6},
+		{true, 0, 6},
+		{true, 40, 6}, // scaled min current 3A covered by grid
+		{true, 60, 0}, // scaled min current 1.5A, pure pv
+		{true, 80, 0},
+	}
+
+	for _, tc := range tc {
+		t.Logf("%+v", tc)
+		lp.MinPVSocScaling = tc.scaling
+		lp.vehicleSoc = tc.soc
+		assert.Equal(t, tc.current, lp.pvMaxCurrent(api.ModeMinPV, sitePower, false, false))
+	}
+}
+
+func TestEffectiveMaxCurrentVehicleLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	vehicle := &struct {
+		*api.MockVehicle
+		*api.MockVehicleCurrentLimit
+	}{
+		api.NewMockVehicle(ctrl),
+		api.NewMockVehicleCurrentLimit(ctrl),
+	}
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.charger = api.NewMockCharger(ctrl)
+	lp.vehicle = vehicle
+
+	vehicle.MockVehicle.EXPECT().OnIdentified().Return(api.ActionConfig{}).AnyTimes()
+
+	assert.Equal(t, 16.0, lp.effectiveMaxCurrent(), "unknown")
+
+	vehicle.MockVehicleCurrentLimit.EXPECT().ChargeCurrentLimit().Return(int64(10), nil)
+	lp.updateVehicleCurrentLimit()
+	assert.Equal(t, 10.0, lp.effectiveMaxCurrent(), "vehicle limit")
+
+	vehicle.MockVehicleCurrentLimit.EXPECT().ChargeCurrentLimit().Return(int64(0), nil)
+	lp.updateVehicleCurrentLimit()
+	assert.Equal(t, 16.0, lp.effectiveMaxCurrent(), "unsupported")
+}
+
+func TestEffectiveVoltage(t *testing.T) {
+	Voltage = 0
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	assert.Equal(t, 230.0, lp.effectiveVoltage(), "nominal voltage")
+
+	Voltage = 240 // V
+	assert.Equal(t, 240.0, lp.effectiveVoltage(), "site voltage")
+
+	Voltage = 230 // V
+	assert.Equal(t, 230.0, lp.effectiveVoltage())
+	assert.Equal(t, 10.0, lp.powerToCurrent(6900, 3))
+
+	lp.Voltage = 120
+	assert.Equal(t, 120.0, lp.effectiveVoltage())
+	assert.Equal(t, 10.0, lp.powerToCurrent(1200, 1))
+}
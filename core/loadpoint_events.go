@@ -0,0 +1,33 @@
+package core
+
+import (
+	"slices"
+
+	"github.com/evcc-io/evcc/core/loadpoint"
+)
+
+// maxEventHistory limits the number of recorded loadpoint events
+const maxEventHistory = 100
+
+// recordEvent adds an event to the event history, dropping the oldest events beyond maxEventHistory
+func (lp *Loadpoint) recordEvent(event string, data any) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.eventHistory = append(lp.eventHistory, loadpoint.EventRecord{
+		Time:  lp.clock.Now(),
+		Event: event,
+		Data:  data,
+	})
+
+	if len(lp.eventHistory) > maxEventHistory {
+		lp.eventHistory = slices.Delete(lp.eventHistory, 0, len(lp.eventHistory)-maxEventHistory)
+	}
+}
+
+// EventHistory returns the recent loadpoint events, oldest first
+func (lp *Loadpoint) EventHistory() []loadpoint.EventRecord {
+	lp.RLock()
+	defer lp.RUnlock()
+	return slices.Clone(lp.eventHistory)
+}
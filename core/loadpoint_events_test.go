@@ -0,0 +1,41 @@
+package core
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventHistory(t *testing.T) {
+	clck := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:   util.NewLogger("foo"),
+		clock: clck,
+	}
+
+	assert.Empty(t, lp.EventHistory())
+
+	for i := range maxEventHistory + 5 {
+		clck.Add(time.Minute)
+		lp.recordEvent(evChargeStart, strconv.Itoa(i))
+	}
+
+	res := lp.EventHistory()
+	require.Len(t, res, maxEventHistory)
+
+	// oldest events dropped
+	assert.Equal(t, "5", res[0].Data)
+	assert.Equal(t, strconv.Itoa(maxEventHistory+4), res[len(res)-1].Data)
+	assert.Equal(t, clck.Now(), res[len(res)-1].Time)
+	assert.Equal(t, evChargeStart, res[0].Event)
+
+	// returned history is a copy
+	res[0].Event = "foo"
+	assert.Equal(t, evChargeStart, lp.EventHistory()[0].Event)
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/evcc-io/evcc/core/session"
+)
+
+// maxVehicleSamples limits the number of in-memory soc and charging curve samples
+const maxVehicleSamples = 1000
+
+// socSample is a vehicle soc reading
+type socSample struct {
+	Time    time.Time `json:"time"`
+	Vehicle string    `json:"vehicle"`
+	Soc     float64   `json:"soc"`
+}
+
+// curveSample is the charge power at a vehicle soc
+type curveSample struct {
+	Vehicle string  `json:"vehicle"`
+	Soc     float64 `json:"soc"`
+	Power   float64 `json:"power"`
+}
+
+// appendSample appends a sample and drops the oldest samples beyond maxVehicleSamples
+func appendSample[T any](samples []T, sample T) []T {
+	samples = append(samples, sample)
+	if len(samples) > maxVehicleSamples {
+		samples = samples[len(samples)-maxVehicleSamples:]
+	}
+	return samples
+}
+
+// recordVehicleData records soc history and charging curve samples
+func (lp *Loadpoint) recordVehicleData() {
+	var title string
+	if v := lp.GetVehicle(); v != nil {
+		title = v.Title()
+	}
+
+	charging := lp.charging()
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.socHistory = appendSample(lp.socHistory, socSample{
+		Time:    lp.clock.Now(),
+		Vehicle: title,
+		Soc:     lp.vehicleSoc,
+	})
+
+	if charging && lp.chargePower > 0 {
+		lp.chargingCurve = appendSample(lp.chargingCurve, curveSample{
+			Vehicle: title,
+			Soc:     lp.vehicleSoc,
+			Power:   lp.chargePower,
+		})
+	}
+}
+
+// ExportVehicleData writes sessions, soc history and charging curve in csv or json format.
+// The csv format contains one row per session, localized according to the context locale.
+func (lp *Loadpoint) ExportVehicleData(ctx context.Context, w io.Writer, format string) error {
+	var sessions session.Sessions
+	if lp.db != nil {
+		var err error
+		if sessions, err = lp.db.LoadpointSessions(); err != nil {
+			return err
+		}
+	}
+
+	switch format {
+	case "csv":
+		return sessions.WriteCsv(ctx, w)
+
+	case "json":
+		lp.RLock()
+		res := struct {
+			Sessions      session.Sessions `json:"sessions"`
+			SocHistory    []socSample      `json:"socHistory"`
+			ChargingCurve []curveSample    `json:"chargingCurve"`
+		}{
+			Sessions:      sessions,
+			SocHistory:    append([]socSample{}, lp.socHistory...),
+			ChargingCurve: append([]curveSample{}, lp.chargingCurve...),
+		}
+		lp.RUnlock()
+
+		if res.Sessions == nil {
+			res.Sessions = session.Sessions{}
+		}
+
+		return json.NewEncoder(w).Encode(res)
+
+	default:
+		return fmt.Errorf("invalid export format: %s not in [csv, json]", format)
+	}
+}
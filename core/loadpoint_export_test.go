@@ -0,0 +1,112 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/session"
+	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/locale"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestExportVehicleData(t *testing.T) {
+	var err error
+	serverdb.Instance, err = serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	db, err := session.NewStore("foo", serverdb.Instance)
+	require.NoError(t, err)
+
+	other, err := session.NewStore("bar", serverdb.Instance)
+	require.NoError(t, err)
+
+	clck := clock.NewMock()
+	solar := 75.0
+
+	for _, energy := range []float64{10, 20} {
+		s := db.New(1000)
+		s.Created = clck.Now()
+		s.Finished = clck.Now().Add(time.Hour)
+		s.Vehicle = "car"
+		s.ChargedEnergy = energy
+		s.SolarPercentage = &solar
+		db.Persist(s)
+	}
+	other.Persist(other.New(0))
+
+	lp := &Loadpoint{
+		log:   util.NewLogger("foo"),
+		clock: clck,
+		db:    db,
+	}
+
+	lp.status = api.StatusC
+	for _, soc := range []float64{20, 30} {
+		lp.vehicleSoc = soc
+		lp.chargePower = 11000
+		lp.recordVehicleData()
+		clck.Add(time.Minute)
+	}
+
+	// csv
+	// captions default to the csv tags without translations
+	locale.Bundle = i18n.NewBundle(language.English)
+	locale.Localizer = i18n.NewLocalizer(locale.Bundle, "en")
+	ctx := context.WithValue(context.Background(), locale.Locale, "en")
+
+	var buf bytes.Buffer
+	require.NoError(t, lp.ExportVehicleData(ctx, &buf, "csv"))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	idx := func(caption string) int {
+		i := slices.Index(rows[0], caption)
+		require.GreaterOrEqual(t, i, 0, caption)
+		return i
+	}
+
+	energy := idx("Charged Energy (kWh)")
+	assert.Equal(t, "car", rows[1][idx("Vehicle")])
+	assert.Equal(t, "10", rows[1][energy])
+	assert.Equal(t, "20", rows[2][energy])
+
+	// json
+	buf.Reset()
+	require.NoError(t, lp.ExportVehicleData(ctx, &buf, "json"))
+
+	var res struct {
+		Sessions      session.Sessions
+		SocHistory    []socSample
+		ChargingCurve []curveSample
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+	assert.Len(t, res.Sessions, 2)
+	assert.Equal(t, []float64{20, 30}, []float64{res.SocHistory[0].Soc, res.SocHistory[1].Soc})
+	assert.Equal(t, []curveSample{{Soc: 20, Power: 11000}, {Soc: 30, Power: 11000}}, res.ChargingCurve)
+
+	assert.Error(t, lp.ExportVehicleData(ctx, &buf, "xlsx"))
+}
+
+func TestAppendSample(t *testing.T) {
+	var samples []int
+	for i := range maxVehicleSamples + 10 {
+		samples = appendSample(samples, i)
+	}
+
+	assert.Len(t, samples, maxVehicleSamples)
+	assert.Equal(t, 10, samples[0])
+}
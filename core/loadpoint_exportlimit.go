@@ -0,0 +1,18 @@
+package core
+
+import "github.com/evcc-io/evcc/core/keys"
+
+// gridExportLimitedSitePower returns the site power with the allowed grid export added,
+// so that only surplus exceeding the export limit is used for charging
+func (lp *Loadpoint) gridExportLimitedSitePower(sitePower float64) float64 {
+	export := max(-sitePower, 0)
+	lp.publish(keys.GridExport, export)
+
+	if lp.GridExportLimit <= 0 {
+		return sitePower
+	}
+
+	lp.log.DEBUG.Printf("grid export limit: %.0fW export, %.0fW allowed", export, lp.GridExportLimit)
+
+	return sitePower + lp.GridExportLimit
+}
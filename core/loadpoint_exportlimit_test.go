@@ -0,0 +1,40 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGridExportLimit(t *testing.T) {
+	Voltage = 230 // V
+
+	lp := &Loadpoint{
+		log:             util.NewLogger("foo"),
+		clock:           clock.NewMock(),
+		charger:         api.NewMockCharger(gomock.NewController(t)),
+		status:          api.StatusC,
+		enabled:         true,
+		phases:          1,
+		minCurrent:      minA,
+		maxCurrent:      maxA,
+		chargeCurrent:   10,
+		GridExportLimit: 920,
+	}
+
+	for _, tc := range []struct {
+		sitePower, current float64
+	}{
+		{-920, 10},  // export at limit
+		{-1840, 14}, // 4A surplus above limit
+		{-5000, maxA},
+		{0, 6}, // 4A below limit
+	} {
+		t.Logf("%+v", tc)
+		assert.Equal(t, tc.current, lp.pvMaxCurrent(api.ModePV, tc.sitePower, false, false))
+	}
+}
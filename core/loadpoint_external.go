@@ -0,0 +1,86 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// externalTimeout is the default validity of an externally commanded charge current
+const externalTimeout = time.Minute
+
+// GetExternalCurrent returns the externally commanded charge current
+func (lp *Loadpoint) GetExternalCurrent() int64 {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.externalCurrent
+}
+
+// SetExternalCurrent sets the externally commanded charge current. Zero disables charging.
+func (lp *Loadpoint) SetExternalCurrent(current int64) error {
+	if !lp.ExternalCurrentControl {
+		return errors.New("external current control not enabled")
+	}
+
+	if current < 0 {
+		return fmt.Errorf("invalid external current: %d", current)
+	}
+
+	if current > 0 {
+		if minCurrent := lp.effectiveMinCurrent(); float64(current) < minCurrent {
+			return fmt.Errorf("external current %dA below min current %.3gA", current, minCurrent)
+		}
+		if maxCurrent := lp.effectiveMaxCurrent(); float64(current) > maxCurrent {
+			return fmt.Errorf("external current %dA above max current %.3gA", current, maxCurrent)
+		}
+	}
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Println("set external current:", current)
+
+	lp.externalCurrent = current
+	lp.externalCurrentUpdated = lp.clock.Now()
+	lp.publish(keys.ExternalCurrent, current)
+
+	lp.requestUpdate()
+
+	return nil
+}
+
+// externalCurrentValue returns the external charge current and if it has been updated within the timeout
+func (lp *Loadpoint) externalCurrentValue() (int64, time.Duration, bool) {
+	lp.RLock()
+	defer lp.RUnlock()
+
+	timeout := lp.ExternalTimeout
+	if timeout <= 0 {
+		timeout = externalTimeout
+	}
+
+	if lp.externalCurrentUpdated.IsZero() {
+		return 0, 0, false
+	}
+
+	age := lp.clock.Since(lp.externalCurrentUpdated)
+
+	return lp.externalCurrent, age, age < timeout
+}
+
+// externalCurrentControl applies the external charge current or disables the charger if it has timed out
+func (lp *Loadpoint) externalCurrentControl() error {
+	current, age, active := lp.externalCurrentValue()
+
+	lp.publish(keys.ExternalCurrentActive, active)
+	lp.publish(keys.ExternalCurrentAge, age)
+
+	if !active {
+		lp.log.DEBUG.Println("external current not updated: charging disabled")
+		return lp.setLimit(0)
+	}
+
+	return lp.setLimit(float64(current))
+}
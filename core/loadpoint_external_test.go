@@ -0,0 +1,91 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSetExternalCurrent(t *testing.T) {
+	lp := &Loadpoint{
+		log:        util.NewLogger("foo"),
+		clock:      clock.NewMock(),
+		minCurrent: 6,
+		maxCurrent: 16,
+	}
+
+	assert.Error(t, lp.SetExternalCurrent(10), "not enabled")
+
+	lp.ExternalCurrentControl = true
+
+	for _, tc := range []struct {
+		current int64
+		err     bool
+	}{
+		{-1, true},
+		{0, false},
+		{5, true},
+		{6, false},
+		{16, false},
+		{17, true},
+	} {
+		t.Log(tc)
+
+		if err := lp.SetExternalCurrent(tc.current); tc.err {
+			assert.Error(t, err)
+		} else {
+			require.NoError(t, err)
+			assert.Equal(t, tc.current, lp.GetExternalCurrent())
+		}
+	}
+}
+
+func TestExternalCurrentControl(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clck := clock.NewMock()
+	charger := api.NewMockCharger(ctrl)
+
+	lp := &Loadpoint{
+		log:                    util.NewLogger("foo"),
+		bus:                    evbus.New(),
+		clock:                  clck,
+		charger:                charger,
+		wakeUpTimer:            NewTimer(),
+		minCurrent:             6,
+		maxCurrent:             16,
+		ExternalCurrentControl: true,
+		ExternalTimeout:        30 * time.Second,
+	}
+
+	// never updated
+	require.NoError(t, lp.externalCurrentControl())
+
+	// enable with external current
+	require.NoError(t, lp.SetExternalCurrent(10))
+	charger.EXPECT().MaxCurrent(int64(10)).Return(nil)
+	charger.EXPECT().Enable(true).Return(nil)
+	require.NoError(t, lp.externalCurrentControl())
+	assert.True(t, lp.enabled)
+
+	// current still valid
+	clck.Add(29 * time.Second)
+	require.NoError(t, lp.externalCurrentControl())
+	assert.True(t, lp.enabled)
+
+	// timeout disables charger
+	clck.Add(time.Second)
+	charger.EXPECT().Enable(false).Return(nil)
+	require.NoError(t, lp.externalCurrentControl())
+	assert.False(t, lp.enabled)
+
+	_, age, active := lp.externalCurrentValue()
+	assert.False(t, active)
+	assert.Equal(t, 30*time.Second, age)
+}
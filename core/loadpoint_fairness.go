@@ -0,0 +1,31 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// addPvFairness accumulates received and entitled pv energy in Wh and publishes the fairness ratio.
+// Counters are reset at the start of each month.
+func (lp *Loadpoint) addPvFairness(received, entitled float64, now time.Time) {
+	lp.Lock()
+
+	if month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()); !month.Equal(lp.pvFairnessMonth) {
+		lp.pvFairnessMonth = month
+		lp.pvEnergyReceived = 0
+		lp.pvEnergyEntitled = 0
+	}
+
+	lp.pvEnergyReceived += received
+	lp.pvEnergyEntitled += entitled
+
+	var ratio float64
+	if lp.pvEnergyEntitled > 0 {
+		ratio = lp.pvEnergyReceived / lp.pvEnergyEntitled
+	}
+
+	lp.Unlock()
+
+	lp.publish(keys.PvFairnessRatio, ratio)
+}
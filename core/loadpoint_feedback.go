@@ -0,0 +1,76 @@
+package core
+
+import (
+	"slices"
+
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+const (
+	feedbackSamples = 10  // number of stable measurements required for correction
+	minCorrection   = 0.8 // lower bound of correction factor
+	maxCorrection   = 1.2 // upper bound of correction factor
+)
+
+// currentSample is a pair of commanded and measured charge current
+type currentSample struct {
+	commanded, measured float64
+}
+
+// updateCurrentFeedback samples commanded vs measured current and updates the correction factor
+func (lp *Loadpoint) updateCurrentFeedback() {
+	if !lp.CurrentFeedback {
+		return
+	}
+
+	command := lp.commandedCurrent
+	stable := command == lp.feedbackCommand
+	lp.feedbackCommand = command
+
+	// only sample if current has settled since last update
+	if !stable || !lp.enabled || !lp.charging() || command <= 0 || len(lp.chargeCurrents) == 0 {
+		return
+	}
+
+	measured := slices.Max(lp.chargeCurrents)
+	if measured <= 0 {
+		return
+	}
+
+	lp.feedbackSamples = append(lp.feedbackSamples, currentSample{command, measured})
+	if len(lp.feedbackSamples) > feedbackSamples {
+		lp.feedbackSamples = lp.feedbackSamples[1:]
+	}
+
+	if len(lp.feedbackSamples) < feedbackSamples {
+		return
+	}
+
+	var commanded, delivered float64
+	for _, s := range lp.feedbackSamples {
+		commanded += s.commanded
+		delivered += s.measured
+	}
+
+	factor := min(max(delivered/commanded, minCorrection), maxCorrection)
+	if factor != lp.correctionFactor {
+		lp.log.DEBUG.Printf("current correction factor: %.3f", factor)
+	}
+
+	lp.correctionFactor = factor
+	lp.publish(keys.CurrentCorrectionFactor, factor)
+}
+
+// correctedCurrent returns the current to be commanded for delivering the requested current
+func (lp *Loadpoint) correctedCurrent(current float64) float64 {
+	if !lp.CurrentFeedback || lp.correctionFactor == 0 {
+		return current
+	}
+
+	minCurrent := lp.effectiveMinCurrent()
+	if current < minCurrent {
+		return current
+	}
+
+	return min(max(current/lp.correctionFactor, minCurrent), lp.effectiveMaxCurrent())
+}
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"testing"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCurrentFeedback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+
+	lp := &Loadpoint{
+		log:             util.NewLogger("foo"),
+		bus:             evbus.New(),
+		clock:           clock.NewMock(),
+		charger:         charger,
+		wakeUpTimer:     NewTimer(),
+		status:          api.StatusC,
+		minCurrent:      6,
+		maxCurrent:      16,
+		CurrentFeedback: true,
+	}
+
+	// charger delivers 10% less than commanded
+	var commanded int64
+	charger.EXPECT().MaxCurrent(gomock.Any()).DoAndReturn(func(current int64) error {
+		commanded = current
+		return nil
+	}).AnyTimes()
+	charger.EXPECT().Enable(true).Return(nil)
+
+	update := func() {
+		require.NoError(t, lp.setLimit(10))
+		lp.chargeCurrents = []float64{0.9 * float64(commanded), 0.9 * float64(commanded), 0.9 * float64(commanded)}
+		lp.updateCurrentFeedback()
+	}
+
+	for range feedbackSamples {
+		update()
+		assert.Equal(t, int64(10), commanded, "no correction before enough samples")
+	}
+
+	update()
+	assert.InDelta(t, 0.9, lp.correctionFactor, 1e-6)
+
+	// corrected command
+	update()
+	assert.Equal(t, int64(11), commanded)
+	assert.Equal(t, 10.0, lp.chargeCurrent, "requested current")
+	assert.InDelta(t, 0.9, lp.correctionFactor, 1e-6)
+}
+
+func TestCorrectedCurrentLimits(t *testing.T) {
+	lp := &Loadpoint{
+		log:             util.NewLogger("foo"),
+		minCurrent:      6,
+		maxCurrent:      16,
+		CurrentFeedback: true,
+	}
+
+	assert.Equal(t, 10.0, lp.correctedCurrent(10), "no correction factor yet")
+
+	lp.correctionFactor = maxCorrection
+	assert.Equal(t, 6.0, lp.correctedCurrent(6), "min current")
+	assert.Equal(t, 0.0, lp.correctedCurrent(0), "disabled")
+
+	lp.correctionFactor = minCorrection
+	assert.Equal(t, 15.0, lp.correctedCurrent(12))
+	assert.Equal(t, 16.0, lp.correctedCurrent(16), "max current")
+}
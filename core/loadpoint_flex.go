@@ -0,0 +1,122 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/planner"
+)
+
+// String returns the slot's time window
+func (s FlexSlot) String() string {
+	return s.Start + "-" + s.End
+}
+
+// window returns the slot's time window containing or following the given time.
+// Windows ending before they start extend to the next day.
+func (s FlexSlot) window(now time.Time) (time.Time, time.Time, error) {
+	return dailyWindow(now, s.Start, s.End)
+}
+
+// dailyWindow returns the HH:MM time window containing or following the given time.
+// Windows ending before they start extend to the next day.
+func dailyWindow(now time.Time, from, to string) (time.Time, time.Time, error) {
+	start, err := time.Parse("15:04", from)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start: %w", err)
+	}
+
+	end, err := time.Parse("15:04", to)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end: %w", err)
+	}
+
+	at := func(day time.Time, t time.Time, offset int) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day()+offset, t.Hour(), t.Minute(), 0, 0, day.Location())
+	}
+
+	var overnight int
+	if !end.After(start) {
+		overnight = 1
+	}
+
+	// window started yesterday
+	if ws, we := at(now, start, -1), at(now, end, overnight-1); !now.Before(ws) && now.Before(we) {
+		return ws, we, nil
+	}
+
+	return at(now, start, 0), at(now, end, overnight), nil
+}
+
+// activeFlexSlot returns the currently active slot and its window end
+func (lp *Loadpoint) activeFlexSlot() (FlexSlot, time.Time, bool) {
+	now := lp.clock.Now()
+
+	for _, slot := range lp.FlexSchedule {
+		if start, end, err := slot.window(now); err == nil && !now.Before(start) && now.Before(end) {
+			return slot, end, true
+		}
+	}
+
+	return FlexSlot{}, time.Time{}, false
+}
+
+// flexRequiredDuration returns the charging duration required for reaching the target soc.
+// Without soc estimation the entire remaining window is used.
+func (lp *Loadpoint) flexRequiredDuration(targetSoc float64, end time.Time) time.Duration {
+	lp.RLock()
+	estimator, vehicleSoc := lp.socEstimator, lp.vehicleSoc
+	lp.RUnlock()
+
+	if estimator == nil || vehicleSoc == 0 {
+		return lp.clock.Until(end)
+	}
+
+	return estimator.RemainingChargeDuration(int(targetSoc), lp.EffectiveMaxPower())
+}
+
+// flexPlanActive returns true if the cheapest plan for the required duration until window end has an active slot
+func (lp *Loadpoint) flexPlanActive(requiredDuration time.Duration, end time.Time) bool {
+	if requiredDuration <= 0 {
+		return false
+	}
+
+	// without planner charge entire window
+	if lp.planner == nil {
+		return true
+	}
+
+	plan, err := lp.GetPlan(end, requiredDuration)
+	if err != nil {
+		lp.log.ERROR.Println("flex schedule:", err)
+	}
+
+	return !planner.SlotAt(lp.clock.Now(), plan).End.IsZero()
+}
+
+// flexCharging charges at the cheapest times within the active flexible schedule window
+func (lp *Loadpoint) flexCharging() error {
+	slot, end, ok := lp.activeFlexSlot()
+
+	var active string
+	if ok {
+		active = slot.String()
+	}
+	lp.publish(keys.ActiveFlexSlot, active)
+
+	if !ok {
+		return lp.setLimit(0)
+	}
+
+	if lp.vehicleSoc > 0 && lp.vehicleSoc >= slot.TargetSoc {
+		lp.log.DEBUG.Printf("flex schedule %s: target soc %.0f%% reached", slot, slot.TargetSoc)
+		return lp.setLimit(0)
+	}
+
+	if !lp.flexPlanActive(lp.flexRequiredDuration(slot.TargetSoc, end), end) {
+		return lp.setLimit(0)
+	}
+
+	return lp.fastCharging()
+}
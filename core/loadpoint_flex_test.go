@@ -0,0 +1,161 @@
+package core
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/planner"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFlexSlotWindow(t *testing.T) {
+	day := time.Date(2024, 3, 12, 0, 0, 0, 0, time.Local)
+	at := func(d, h, m int) time.Time {
+		return day.AddDate(0, 0, d).Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute)
+	}
+
+	tc := []struct {
+		slot       FlexSlot
+		now        time.Time
+		start, end time.Time
+	}{
+		{FlexSlot{Start: "07:00", End: "09:30"}, at(0, 6, 0), at(0, 7, 0), at(0, 9, 30)},
+		{FlexSlot{Start: "07:00", End: "09:30"}, at(0, 8, 0), at(0, 7, 0), at(0, 9, 30)},
+		{FlexSlot{Start: "07:00", End: "09:30"}, at(0, 10, 0), at(0, 7, 0), at(0, 9, 30)},
+		{FlexSlot{Start: "22:00", End: "06:00"}, at(0, 23, 0), at(0, 22, 0), at(1, 6, 0)},
+		{FlexSlot{Start: "22:00", End: "06:00"}, at(0, 5, 0), at(-1, 22, 0), at(0, 6, 0)},
+		{FlexSlot{Start: "22:00", End: "06:00"}, at(0, 12, 0), at(0, 22, 0), at(1, 6, 0)},
+	}
+
+	for _, tc := range tc {
+		start, end, err := tc.slot.window(tc.now)
+		require.NoError(t, err)
+		assert.Equal(t, tc.start, start, tc.now)
+		assert.Equal(t, tc.end, end, tc.now)
+	}
+
+	_, _, err := FlexSlot{Start: "7", End: "09:00"}.window(day)
+	assert.Error(t, err)
+}
+
+func TestFlexSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clck := clock.NewMock()
+
+	// start at 18:00 local time
+	now := time.Now()
+	clck.Set(time.Date(now.Year(), now.Month(), now.Day(), 18, 0, 0, 0, time.Local))
+
+	// hourly prices from 18:00 to 09:00, cheapest at 02:00 and 03:00
+	prices := []float64{30, 30, 30, 30, 25, 20, 15, 12, 10, 10, 12, 20, 25, 30, 30}
+	var rates api.Rates
+	for i, p := range prices {
+		start := clck.Now().Add(time.Duration(i) * time.Hour)
+		rates = append(rates, api.Rate{Start: start, End: start.Add(time.Hour), Price: p})
+	}
+
+	tariff := api.NewMockTariff(ctrl)
+	tariff.EXPECT().Rates().DoAndReturn(func() (api.Rates, error) {
+		return slices.Clone(rates), nil
+	}).AnyTimes()
+
+	lp := &Loadpoint{
+		log:          util.NewLogger("foo"),
+		clock:        clck,
+		planner:      planner.New(util.NewLogger("foo"), tariff, planner.WithClock(clck)),
+		FlexSchedule: []FlexSlot{{Start: "22:00", End: "06:00", TargetSoc: 80}},
+	}
+
+	var active []int
+	for h := range 15 {
+		if _, end, ok := lp.activeFlexSlot(); ok {
+			assert.Equal(t, 6, end.Hour())
+
+			// two hours of charging required
+			required := 2*time.Hour - time.Duration(len(active))*time.Hour
+
+			if lp.flexPlanActive(required, end) {
+				active = append(active, (18+h)%24)
+			}
+		}
+
+		clck.Add(time.Hour)
+	}
+
+	assert.Equal(t, []int{2, 3}, active)
+}
+
+func TestFlexScheduleOutsideWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+	clck := clock.NewMock()
+
+	now := time.Now()
+	clck.Set(time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, time.Local))
+
+	lp := newChargerTestLoadpoint(clck, charger)
+	lp.FlexSchedule = []FlexSlot{{Start: "22:00", End: "06:00", TargetSoc: 80}}
+	lp.enabled = true
+
+	// outside window
+	charger.EXPECT().Enable(false).Return(nil)
+	require.NoError(t, lp.flexCharging())
+	assert.False(t, lp.enabled)
+
+	// within window, no tariff
+	clck.Add(11 * time.Hour)
+	charger.EXPECT().MaxCurrent(int64(16)).Return(nil)
+	charger.EXPECT().Enable(true).Return(nil)
+	require.NoError(t, lp.flexCharging())
+	assert.True(t, lp.enabled)
+
+	// target soc reached
+	lp.vehicleSoc = 80
+	charger.EXPECT().Enable(false).Return(nil)
+	require.NoError(t, lp.flexCharging())
+	assert.False(t, lp.enabled)
+}
+
+func TestFlexScheduleModeNow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+	clck := clock.NewMock()
+
+	// outside flex window
+	now := time.Now()
+	clck.Set(time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, time.Local))
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		bus:           evbus.New(),
+		clock:         clck,
+		charger:       charger,
+		chargeMeter:   &Null{}, // silence nil panics
+		chargeRater:   &Null{}, // silence nil panics
+		chargeTimer:   &Null{}, // silence nil panics
+		wakeUpTimer:   NewTimer(),
+		sessionEnergy: NewEnergyMetrics(),
+		minCurrent:    minA,
+		maxCurrent:    maxA,
+		status:        api.StatusB,
+		mode:          api.ModeNow,
+		Mode_:         api.ModeOff, // default mode
+		FlexSchedule:  []FlexSlot{{Start: "22:00", End: "06:00", TargetSoc: 80}},
+	}
+
+	attachListeners(t, lp)
+
+	charger.EXPECT().Enabled().Return(lp.enabled, nil)
+	charger.EXPECT().Status().Return(api.StatusB, nil)
+	charger.EXPECT().MaxCurrent(int64(maxA)).Return(nil)
+	lp.Update(500, false, false, false, 0, nil, nil)
+
+	assert.Equal(t, float64(maxA), lp.chargeCurrent)
+}
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// GetForceCharge returns if charging is forced until the vehicle disconnects
+func (lp *Loadpoint) GetForceCharge() bool {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.forceCharge
+}
+
+// SetForceCharge forces charging in immediate mode regardless of the charge mode until the vehicle disconnects
+func (lp *Loadpoint) SetForceCharge(enable bool) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Println("set force charge:", enable)
+
+	if lp.forceCharge != enable {
+		lp.forceCharge = enable
+		lp.publish(keys.ForceCharge, enable)
+		lp.requestUpdate()
+	}
+}
+
+// clearForceCharge ends forced charging
+func (lp *Loadpoint) clearForceCharge() {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.forceCharge = false
+	lp.publish(keys.ForceCharge, false)
+}
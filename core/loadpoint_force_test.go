@@ -0,0 +1,104 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestForceCharge(t *testing.T) {
+	clck := clock.NewMock()
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		bus:           evbus.New(),
+		clock:         clck,
+		charger:       charger,
+		chargeMeter:   &Null{}, // silence nil panics
+		chargeRater:   &Null{}, // silence nil panics
+		chargeTimer:   &Null{}, // silence nil panics
+		wakeUpTimer:   NewTimer(),
+		sessionEnergy: NewEnergyMetrics(),
+		minCurrent:    minA,
+		maxCurrent:    maxA,
+		status:        api.StatusB,
+		mode:          api.ModeOff,
+		Mode_:         api.ModeOff, // default mode
+	}
+
+	attachListeners(t, lp)
+
+	t.Log("force charge despite mode off")
+	lp.SetForceCharge(true)
+	charger.EXPECT().Enabled().Return(lp.enabled, nil)
+	charger.EXPECT().Status().Return(api.StatusB, nil)
+	charger.EXPECT().MaxCurrent(int64(maxA)).Return(nil)
+	lp.Update(500, false, false, false, 0, nil, nil)
+
+	assert.Equal(t, api.ModeOff, lp.GetMode(), "configured mode unchanged")
+	assert.Equal(t, float64(maxA), lp.chargeCurrent)
+
+	t.Log("return to mode")
+	lp.SetForceCharge(false)
+	charger.EXPECT().Enabled().Return(lp.enabled, nil)
+	charger.EXPECT().Status().Return(api.StatusB, nil)
+	charger.EXPECT().Enable(false).Return(nil)
+	lp.Update(500, false, false, false, 0, nil, nil)
+
+	assert.False(t, lp.enabled)
+
+	t.Log("force charge ends when disconnected")
+	lp.SetForceCharge(true)
+	clck.Add(5 * time.Minute)
+	charger.EXPECT().Enabled().Return(lp.enabled, nil)
+	charger.EXPECT().Status().Return(api.StatusA, nil)
+	lp.Update(500, false, false, false, 0, nil, nil)
+
+	assert.False(t, lp.GetForceCharge())
+}
+
+func TestForceChargeFlexSchedule(t *testing.T) {
+	clck := clock.NewMock()
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+
+	// outside flex window
+	now := time.Now()
+	clck.Set(time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, time.Local))
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		bus:           evbus.New(),
+		clock:         clck,
+		charger:       charger,
+		chargeMeter:   &Null{}, // silence nil panics
+		chargeRater:   &Null{}, // silence nil panics
+		chargeTimer:   &Null{}, // silence nil panics
+		wakeUpTimer:   NewTimer(),
+		sessionEnergy: NewEnergyMetrics(),
+		minCurrent:    minA,
+		maxCurrent:    maxA,
+		status:        api.StatusB,
+		mode:          api.ModePV,
+		Mode_:         api.ModeOff, // default mode
+		FlexSchedule:  []FlexSlot{{Start: "22:00", End: "06:00", TargetSoc: 80}},
+	}
+
+	attachListeners(t, lp)
+
+	lp.SetForceCharge(true)
+	charger.EXPECT().Enabled().Return(lp.enabled, nil)
+	charger.EXPECT().Status().Return(api.StatusB, nil)
+	charger.EXPECT().MaxCurrent(int64(maxA)).Return(nil)
+	lp.Update(500, false, false, false, 0, nil, nil)
+
+	assert.Equal(t, float64(maxA), lp.chargeCurrent)
+}
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// granularityCommand sends a test current to the charger and reads back the applied value
+func (lp *Loadpoint) granularityCommand(cg api.CurrentGetter, current float64) (float64, error) {
+	var err error
+	if charger, ok := lp.charger.(api.ChargerEx); ok {
+		err = charger.MaxCurrentMillis(current)
+	} else {
+		err = lp.charger.MaxCurrent(int64(current))
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return cg.GetMaxCurrent()
+}
+
+// discoverCurrentGranularity determines the charger's current step size.
+// It binary-searches the smallest increment above min current that the charger does not round away
+// and uses the read back difference as step size.
+func (lp *Loadpoint) discoverCurrentGranularity() (float64, error) {
+	cg, ok := lp.charger.(api.CurrentGetter)
+	if !ok {
+		return 0, api.ErrNotAvailable
+	}
+
+	resolution := 1.0
+	if _, ok := lp.charger.(api.ChargerEx); ok {
+		resolution = 0.1
+	}
+
+	minCurrent, maxCurrent := lp.GetMinCurrent(), lp.GetMaxCurrent()
+
+	base, err := lp.granularityCommand(cg, minCurrent)
+	if err != nil {
+		return 0, err
+	}
+
+	// search increments in units of resolution
+	lo, hi := 0, int(math.Round((maxCurrent-minCurrent)/resolution))
+
+	top, err := lp.granularityCommand(cg, minCurrent+float64(hi)*resolution)
+	if err != nil {
+		return 0, err
+	}
+	if top == base {
+		return 0, fmt.Errorf("charger does not apply current changes between %.3gA and %.3gA", minCurrent, maxCurrent)
+	}
+
+	// invariant: lo reads back base, hi reads back a different value
+	for hi-lo > 1 {
+		mid := (lo + hi) / 2
+
+		f, err := lp.granularityCommand(cg, minCurrent+float64(mid)*resolution)
+		if err != nil {
+			return 0, err
+		}
+
+		if f == base {
+			lo = mid
+		} else {
+			hi, top = mid, f
+		}
+	}
+
+	return math.Round((top-base)/resolution) * resolution, nil
+}
+
+// detectCurrentGranularity runs the current step discovery once and publishes the result.
+// Discovery is skipped while a vehicle is charging since it steps the current up to max current.
+func (lp *Loadpoint) detectCurrentGranularity() {
+	if !lp.DiscoverCurrentGranularity || lp.detectedCurrentStep > 0 {
+		return
+	}
+
+	if status, err := lp.charger.Status(); err != nil || status == api.StatusC {
+		lp.log.WARN.Println("current granularity: discovery skipped, vehicle charging or status unknown")
+		return
+	}
+
+	step, err := lp.discoverCurrentGranularity()
+	if err != nil {
+		lp.log.WARN.Printf("current granularity: %v", err)
+		return
+	}
+
+	// current has been modified during discovery
+	lp.chargeCurrent = 0
+
+	lp.log.INFO.Printf("current granularity: %.3gA", step)
+	lp.detectedCurrentStep = step
+	lp.publish(keys.DetectedCurrentStep, step)
+}
+
+// roundToCurrentStep rounds the current down to the detected charger step size.
+// Currents at or above min current are never rounded below min current.
+func (lp *Loadpoint) roundToCurrentStep(current float64) float64 {
+	if lp.detectedCurrentStep <= 0 {
+		return current
+	}
+
+	res := math.Floor(current/lp.detectedCurrentStep+1e-9) * lp.detectedCurrentStep
+
+	if minCurrent := lp.effectiveMinCurrent(); current >= minCurrent && res < minCurrent {
+		return minCurrent
+	}
+
+	return res
+}
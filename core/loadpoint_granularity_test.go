@@ -0,0 +1,116 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// steppedChargerStub rounds commanded currents down to a fixed step size
+type steppedChargerStub struct {
+	*api.MockCharger
+	step    float64
+	current float64
+}
+
+func (c *steppedChargerStub) MaxCurrentMillis(current float64) error {
+	c.current = math.Floor(current/c.step) * c.step
+	return nil
+}
+
+func (c *steppedChargerStub) GetMaxCurrent() (float64, error) {
+	return c.current, nil
+}
+
+func TestDiscoverCurrentGranularity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	for _, step := range []float64{0.5, 1, 2} {
+		t.Log(step)
+
+		charger := &steppedChargerStub{MockCharger: api.NewMockCharger(ctrl), step: step}
+		charger.EXPECT().Status().Return(api.StatusB, nil)
+
+		lp := &Loadpoint{
+			log:                        util.NewLogger("foo"),
+			charger:                    charger,
+			minCurrent:                 6,
+			maxCurrent:                 16,
+			DiscoverCurrentGranularity: true,
+		}
+
+		lp.detectCurrentGranularity()
+		assert.Equal(t, step, lp.detectedCurrentStep)
+	}
+}
+
+func TestDiscoverCurrentGranularityUnsupported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := &Loadpoint{
+		log:                        util.NewLogger("foo"),
+		charger:                    api.NewMockCharger(ctrl),
+		minCurrent:                 6,
+		maxCurrent:                 16,
+		DiscoverCurrentGranularity: true,
+	}
+
+	_, err := lp.discoverCurrentGranularity()
+	require.ErrorIs(t, err, api.ErrNotAvailable)
+
+	lp.charger.(*api.MockCharger).EXPECT().Status().Return(api.StatusB, nil)
+	lp.detectCurrentGranularity()
+	assert.Zero(t, lp.detectedCurrentStep)
+}
+
+func TestDiscoverCurrentGranularityCharging(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	// no current commands expected while charging
+	charger := &steppedChargerStub{MockCharger: api.NewMockCharger(ctrl), step: 1}
+	charger.EXPECT().Status().Return(api.StatusC, nil)
+
+	lp := &Loadpoint{
+		log:                        util.NewLogger("foo"),
+		charger:                    charger,
+		minCurrent:                 6,
+		maxCurrent:                 16,
+		DiscoverCurrentGranularity: true,
+	}
+
+	lp.detectCurrentGranularity()
+	assert.Zero(t, lp.detectedCurrentStep)
+	assert.Zero(t, charger.current)
+}
+
+func TestRoundToCurrentStep(t *testing.T) {
+	lp := &Loadpoint{detectedCurrentStep: 2, minCurrent: 6}
+
+	for _, tc := range []struct {
+		current, expected float64
+	}{
+		{0, 0},
+		{6, 6},
+		{7.9, 6},
+		{8, 8},
+		{15.5, 14},
+	} {
+		t.Log(tc)
+		assert.Equal(t, tc.expected, lp.roundToCurrentStep(tc.current))
+	}
+
+	// never round below min current
+	lp.minCurrent = 7
+	assert.Equal(t, 7.0, lp.roundToCurrentStep(7))
+	assert.Equal(t, 7.0, lp.roundToCurrentStep(7.9))
+	assert.Equal(t, 8.0, lp.roundToCurrentStep(8.5))
+	assert.Equal(t, 4.0, lp.roundToCurrentStep(5))
+
+	lp.detectedCurrentStep = 0
+	assert.Equal(t, 7.9, lp.roundToCurrentStep(7.9))
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// green charging rules
+const (
+	greenReasonPV      = "pv"      // pv surplus available
+	greenReasonPrice   = "price"   // tariff below smart cost limit
+	greenReasonCo2     = "co2"     // grid co2 intensity below green threshold
+	greenReasonUrgency = "urgency" // departure requires charging now
+)
+
+// departureUrgent returns true if the remaining plan duration does not fit before the plan time anymore
+func (lp *Loadpoint) departureUrgent() bool {
+	planTime := lp.EffectivePlanTime()
+	if planTime.IsZero() {
+		return false
+	}
+
+	goal, _ := lp.GetPlanGoal()
+	requiredDuration := lp.GetPlanRequiredDuration(goal, lp.EffectiveMaxPower())
+
+	return requiredDuration > 0 && requiredDuration >= lp.clock.Until(planTime)
+}
+
+// co2BelowThreshold returns true if the current grid co2 intensity is below the green threshold
+func (lp *Loadpoint) co2BelowThreshold() bool {
+	if lp.GreenThreshold <= 0 || lp.co2Tariff == nil {
+		return false
+	}
+
+	rates, err := lp.co2Tariff.Rates()
+	if err != nil {
+		lp.log.ERROR.Printf("co2 tariff: %v", err)
+		return false
+	}
+
+	rate, err := rates.Current(lp.clock.Now())
+	if err != nil {
+		lp.log.DEBUG.Printf("co2 tariff: %v", err)
+		return false
+	}
+
+	lp.publish(keys.CarbonIntensity, rate.Price)
+
+	return rate.Price < lp.GreenThreshold
+}
+
+// greenCharging charges from pv surplus, cheap grid periods, clean grid periods or if departure is urgent, in this order
+func (lp *Loadpoint) greenCharging(sitePower float64, cheap, batteryBuffered, batteryStart bool) error {
+	var reason string
+	defer func() {
+		lp.publish(keys.GreenChargeReason, reason)
+	}()
+
+	if targetCurrent := lp.pvMaxCurrent(api.ModePV, sitePower, batteryBuffered, batteryStart); targetCurrent > 0 {
+		reason = greenReasonPV
+		return lp.setLimit(targetCurrent)
+	}
+
+	switch {
+	case cheap:
+		reason = greenReasonPrice
+	case lp.co2BelowThreshold():
+		reason = greenReasonCo2
+	case lp.departureUrgent():
+		reason = greenReasonUrgency
+	default:
+		return lp.setLimit(0)
+	}
+
+	lp.log.DEBUG.Printf("green charging: %s", reason)
+
+	err := lp.fastCharging()
+	lp.resetPhaseTimer()
+	lp.elapsePVTimer() // let PV rule disable immediately afterwards
+
+	return err
+}
@@ -0,0 +1,119 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDepartureUrgent(t *testing.T) {
+	clck := clock.NewMock()
+
+	Voltage = 230 // V
+
+	lp := newChargerTestLoadpoint(clck, nil)
+	lp.phases = 1
+	lp.sessionEnergy = NewEnergyMetrics()
+
+	assert.False(t, lp.departureUrgent(), "no plan")
+
+	// 10kWh @ 3.68kW
+	lp.planEnergy = 10
+	lp.planTime = clck.Now().Add(4 * time.Hour)
+	assert.False(t, lp.departureUrgent())
+
+	lp.planTime = clck.Now().Add(2 * time.Hour)
+	assert.True(t, lp.departureUrgent())
+}
+
+func TestGreenCharging(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clck := clock.NewMock()
+	clck.Add(time.Hour) // avoid time.IsZero
+	charger := api.NewMockCharger(ctrl)
+
+	Voltage = 230 // V
+
+	lp := newChargerTestLoadpoint(clck, charger)
+	lp.phases = 1
+	lp.status = api.StatusC
+	lp.sessionEnergy = NewEnergyMetrics()
+
+	// no pv, no cheap tariff, no plan
+	require.NoError(t, lp.greenCharging(1000, false, false, false))
+	assert.False(t, lp.enabled)
+
+	// cheap tariff
+	charger.EXPECT().MaxCurrent(int64(16)).Return(nil)
+	charger.EXPECT().Enable(true).Return(nil)
+	require.NoError(t, lp.greenCharging(1000, true, false, false))
+	assert.True(t, lp.enabled)
+	assert.Equal(t, 16.0, lp.chargeCurrent)
+
+	// pv surplus takes precedence: 16A + (-1000W / 230V)
+	lp.chargeCurrent = 10
+	charger.EXPECT().MaxCurrent(int64(14)).Return(nil)
+	require.NoError(t, lp.greenCharging(-1000, true, false, false))
+	assert.Equal(t, 14.0, lp.chargeCurrent)
+
+	// departure urgency
+	lp.planEnergy = 10
+	lp.planTime = clck.Now().Add(2 * time.Hour)
+	lp.elapsePVTimer()
+	charger.EXPECT().MaxCurrent(int64(16)).Return(nil)
+	require.NoError(t, lp.greenCharging(5000, false, false, false))
+	assert.Equal(t, 16.0, lp.chargeCurrent)
+
+	// nothing applies anymore
+	lp.planEnergy = 0
+	lp.planTime = time.Time{}
+	charger.EXPECT().Enable(false).Return(nil)
+	require.NoError(t, lp.greenCharging(5000, false, false, false))
+	assert.False(t, lp.enabled)
+}
+
+func TestGreenChargingCo2(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clck := clock.NewMock()
+	clck.Add(time.Hour) // avoid time.IsZero
+	charger := api.NewMockCharger(ctrl)
+	tariff := api.NewMockTariff(ctrl)
+
+	Voltage = 230 // V
+
+	lp := newChargerTestLoadpoint(clck, charger)
+	lp.phases = 1
+	lp.status = api.StatusC
+	lp.sessionEnergy = NewEnergyMetrics()
+	lp.co2Tariff = tariff
+
+	rates := func(co2 float64) {
+		tariff.EXPECT().Rates().Return(api.Rates{{
+			Start: clck.Now().Add(-time.Hour),
+			End:   clck.Now().Add(time.Hour),
+			Price: co2,
+		}}, nil)
+	}
+
+	// threshold disabled
+	require.NoError(t, lp.greenCharging(1000, false, false, false))
+	assert.False(t, lp.enabled)
+
+	// dirty grid
+	lp.GreenThreshold = 200
+	rates(250)
+	require.NoError(t, lp.greenCharging(1000, false, false, false))
+	assert.False(t, lp.enabled)
+
+	// clean grid
+	rates(150)
+	charger.EXPECT().MaxCurrent(int64(16)).Return(nil)
+	charger.EXPECT().Enable(true).Return(nil)
+	require.NoError(t, lp.greenCharging(1000, false, false, false))
+	assert.True(t, lp.enabled)
+}
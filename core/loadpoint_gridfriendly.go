@@ -0,0 +1,91 @@
+package core
+
+import (
+	"time"
+)
+
+// grid friendly frequency defaults
+const (
+	gridFriendlyEnableFrequency  = 50.2 // Hz
+	gridFriendlyDisableFrequency = 50.0 // Hz
+)
+
+// gridFriendlyFrequencies returns the effective enable and disable frequencies
+func (lp *Loadpoint) gridFriendlyFrequencies() (float64, float64) {
+	enable, disable := lp.EnableFrequency, lp.DisableFrequency
+	if enable <= 0 {
+		enable = gridFriendlyEnableFrequency
+	}
+	if disable <= 0 {
+		disable = gridFriendlyDisableFrequency
+	}
+	return enable, disable
+}
+
+// gridFriendlyCurrent returns the charge current depending on grid frequency.
+// Charging is enabled above the enable and disabled below the disable frequency, subject to the pv timers.
+func (lp *Loadpoint) gridFriendlyCurrent() float64 {
+	maxCurrent := lp.effectiveMaxCurrent()
+
+	if lp.gridFrequencyMeter == nil {
+		lp.log.WARN.Println("grid friendly charging: missing grid frequency meter")
+		return 0
+	}
+
+	// keep state if frequency is unknown
+	current := 0.0
+	if lp.enabled {
+		current = maxCurrent
+	}
+
+	f, err := lp.gridFrequencyMeter.Frequency()
+	if err != nil {
+		lp.log.ERROR.Printf("grid frequency: %v", err)
+		return current
+	}
+
+	enableFrequency, disableFrequency := lp.gridFriendlyFrequencies()
+
+	var (
+		delay  time.Duration
+		action string
+	)
+
+	switch {
+	case lp.enabled && f <= disableFrequency:
+		lp.log.DEBUG.Printf("grid frequency %.3gHz <= %.3gHz disable frequency", f, disableFrequency)
+		delay, action = lp.Disable.Delay, pvDisable
+
+	case !lp.enabled && f >= enableFrequency:
+		lp.log.DEBUG.Printf("grid frequency %.3gHz >= %.3gHz enable frequency", f, enableFrequency)
+		delay, action = lp.Enable.Delay, pvEnable
+
+	default:
+		lp.resetPVTimer()
+		return current
+	}
+
+	if lp.pvTimer.IsZero() {
+		lp.log.DEBUG.Printf("pv %s timer start: %v", action, delay)
+		lp.pvTimer = lp.clock.Now()
+	}
+
+	lp.publishTimer(pvTimer, delay, action)
+
+	if lp.clock.Since(lp.pvTimer) < delay {
+		return current
+	}
+
+	lp.log.DEBUG.Printf("pv %s timer elapsed", action)
+
+	if action == pvEnable {
+		return maxCurrent
+	}
+
+	return 0
+}
+
+// gridFriendlyCharging charges at max current while grid frequency indicates surplus generation
+func (lp *Loadpoint) gridFriendlyCharging() error {
+	return lp.setLimit(lp.gridFriendlyCurrent())
+}
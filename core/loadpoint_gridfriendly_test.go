@@ -0,0 +1,81 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+type frequencyStub struct {
+	f   float64
+	err error
+}
+
+func (m *frequencyStub) Frequency() (float64, error) {
+	return m.f, m.err
+}
+
+func TestGridFriendlyCurrent(t *testing.T) {
+	clck := clock.NewMock()
+	fm := &frequencyStub{f: 50.1}
+
+	lp := &Loadpoint{
+		log:                util.NewLogger("foo"),
+		clock:              clck,
+		minCurrent:         6,
+		maxCurrent:         16,
+		gridFrequencyMeter: fm,
+		Enable:             ThresholdConfig{Delay: time.Minute},
+		Disable:            ThresholdConfig{Delay: 3 * time.Minute},
+	}
+
+	// within hysteresis
+	assert.Equal(t, 0.0, lp.gridFriendlyCurrent())
+	assert.True(t, lp.pvTimer.IsZero())
+
+	// enable timer
+	fm.f = 50.2
+	assert.Equal(t, 0.0, lp.gridFriendlyCurrent())
+	assert.False(t, lp.pvTimer.IsZero())
+
+	clck.Add(time.Minute)
+	assert.Equal(t, 16.0, lp.gridFriendlyCurrent())
+	lp.enabled = true
+
+	// keep charging within hysteresis
+	fm.f = 50.1
+	assert.Equal(t, 16.0, lp.gridFriendlyCurrent())
+	assert.True(t, lp.pvTimer.IsZero())
+
+	// keep state on error
+	fm.err = errors.New("foo")
+	assert.Equal(t, 16.0, lp.gridFriendlyCurrent())
+	fm.err = nil
+
+	// disable timer
+	fm.f = 49.9
+	assert.Equal(t, 16.0, lp.gridFriendlyCurrent())
+
+	clck.Add(2 * time.Minute)
+	assert.Equal(t, 16.0, lp.gridFriendlyCurrent())
+
+	clck.Add(time.Minute)
+	assert.Equal(t, 0.0, lp.gridFriendlyCurrent())
+}
+
+func TestGridFriendlyFrequencies(t *testing.T) {
+	lp := &Loadpoint{}
+
+	enable, disable := lp.gridFriendlyFrequencies()
+	assert.Equal(t, 50.2, enable)
+	assert.Equal(t, 50.0, disable)
+
+	lp.EnableFrequency = 50.1
+	enable, disable = lp.gridFriendlyFrequencies()
+	assert.Equal(t, 50.1, enable)
+	assert.Equal(t, 50.0, disable)
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+const historyLogMaxSize = 10 << 20 // rotate charge history log at 10MB by default
+
+// SessionRecord is a charge history log entry
+type SessionRecord struct {
+	Time           time.Time      `json:"time"`
+	Vehicle        string         `json:"vehicle,omitempty"`
+	ChargedEnergy  float64        `json:"chargedEnergy"`  // kWh
+	ChargeDuration string         `json:"chargeDuration"` // Go duration format, e.g. 1h23m0s
+	AvgPower       float64        `json:"avgPower"`       // W
+	Mode           api.ChargeMode `json:"mode"`
+	Cost           *float64       `json:"cost,omitempty"` // nil if no tariff is available
+}
+
+// historyLogFile returns the loadpoint's charge history log file name
+func historyLogFile(settings *Settings) string {
+	name := "loadpoint"
+	if settings != nil && settings.Key != "" {
+		name = strings.TrimSuffix(settings.Key, ".")
+	}
+	return name + "-history.jsonl"
+}
+
+// historyMaxSize returns the effective charge history log rotation size
+func (lp *Loadpoint) historyMaxSize() int64 {
+	if lp.History.MaxSize > 0 {
+		return lp.History.MaxSize
+	}
+	return historyLogMaxSize
+}
+
+// sessionRecord returns the charge history log entry of the current session
+func (lp *Loadpoint) sessionRecord() SessionRecord {
+	energy := lp.getChargedEnergy()
+
+	var power float64
+	if duration := lp.chargeDuration; duration > 0 {
+		power = energy / duration.Hours()
+	}
+
+	var title string
+	if v := lp.GetVehicle(); v != nil {
+		title = v.Title()
+	}
+
+	return SessionRecord{
+		Time:           lp.clock.Now(),
+		Vehicle:        title,
+		ChargedEnergy:  energy / 1e3,
+		ChargeDuration: lp.chargeDuration.Round(time.Second).String(),
+		AvgPower:       power,
+		Mode:           lp.GetMode(),
+		Cost:           lp.sessionEnergy.Price(),
+	}
+}
+
+// logSessionHistory appends the finished session to the charge history log if energy has been charged
+func (lp *Loadpoint) logSessionHistory() {
+	if lp.historyLog == nil || lp.getChargedEnergy() <= 0 {
+		return
+	}
+
+	b, err := json.Marshal(lp.sessionRecord())
+	if err != nil {
+		lp.log.ERROR.Printf("charge history: %v", err)
+		return
+	}
+
+	if _, err := lp.historyLog.Write(append(b, '\n')); err != nil {
+		lp.log.ERROR.Printf("charge history: %v", err)
+	}
+}
+
+// LoadHistory reads all session records from a charge history log
+func LoadHistory(path string) ([]SessionRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var res []SessionRecord
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var rec SessionRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, err
+		}
+
+		res = append(res, rec)
+	}
+
+	return res, scanner.Err()
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionHistory(t *testing.T) {
+	clck := clock.NewMock()
+	clck.Set(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	path := filepath.Join(t.TempDir(), historyLogFile(&Settings{Key: "lp1."}))
+	assert.True(t, strings.HasSuffix(path, "lp1-history.jsonl"))
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		clock:         clck,
+		sessionEnergy: NewEnergyMetrics(),
+		historyLog:    &rotatingFile{path: path, maxSize: historyLogMaxSize},
+	}
+
+	price := 0.3
+
+	for _, tc := range []struct {
+		mode     api.ChargeMode
+		energy   float64
+		duration time.Duration
+		price    *float64
+	}{
+		{api.ModePV, 11, 2 * time.Hour, nil},
+		{api.ModeNow, 0, 0, nil}, // not logged
+		{api.ModeNow, 10, 83 * time.Minute, &price},
+	} {
+		lp.mode = tc.mode
+		lp.chargeDuration = tc.duration
+		lp.sessionEnergy.Reset()
+		lp.sessionEnergy.SetEnvironment(0, tc.price, nil)
+		lp.sessionEnergy.Update(tc.energy)
+
+		lp.logSessionHistory()
+		clck.Add(24 * time.Hour)
+	}
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	require.Len(t, lines, 2)
+	assert.JSONEq(t, `{"time":"2024-03-01T12:00:00Z","chargedEnergy":11,"chargeDuration":"2h0m0s","avgPower":5500,"mode":"pv"}`, lines[0])
+
+	res, err := LoadHistory(path)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+
+	assert.Equal(t, time.Date(2024, 3, 3, 12, 0, 0, 0, time.UTC), res[1].Time.UTC())
+	assert.Equal(t, 10.0, res[1].ChargedEnergy)
+	assert.Equal(t, "1h23m0s", res[1].ChargeDuration)
+	assert.Equal(t, api.ModeNow, res[1].Mode)
+	require.NotNil(t, res[1].Cost)
+	assert.InDelta(t, 3.0, *res[1].Cost, 1e-9)
+}
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// parseHoliday validates a holiday date in YYYY-MM-DD or recurring MM-DD format
+func parseHoliday(date string) error {
+	layout := "2006-01-02"
+	if len(date) == len("01-02") {
+		layout = "01-02"
+	}
+
+	if _, err := time.Parse(layout, date); err != nil {
+		return fmt.Errorf("invalid holiday: %s", date)
+	}
+
+	return nil
+}
+
+// isHoliday returns true if the date matches any full or recurring holiday
+func isHoliday(now time.Time, dates []string) bool {
+	return slices.Contains(dates, now.Format("2006-01-02")) || slices.Contains(dates, now.Format("01-02"))
+}
+
+// holidayModeActive returns true if charging is disabled due to a configured holiday
+func (lp *Loadpoint) holidayModeActive() bool {
+	res := isHoliday(lp.clock.Now(), lp.HolidayDates)
+	lp.publish(keys.HolidayModeActive, res)
+	return res
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestParseHoliday(t *testing.T) {
+	for _, tc := range []struct {
+		date string
+		err  bool
+	}{
+		{"2024-08-01", false},
+		{"12-24", false},
+		{"02-29", false},
+		{"2024-13-01", true},
+		{"12-32", true},
+		{"24.12.", true},
+		{"", true},
+	} {
+		t.Log(tc)
+		assert.Equal(t, tc.err, parseHoliday(tc.date) != nil)
+	}
+}
+
+func TestHolidayModeActive(t *testing.T) {
+	clck := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:          util.NewLogger("foo"),
+		clock:        clck,
+		HolidayDates: []string{"2024-08-01", "12-24"},
+	}
+
+	for _, tc := range []struct {
+		now    time.Time
+		active bool
+	}{
+		{time.Date(2024, 8, 1, 0, 0, 0, 0, time.Local), true},
+		{time.Date(2024, 8, 1, 23, 59, 0, 0, time.Local), true},
+		{time.Date(2025, 8, 1, 12, 0, 0, 0, time.Local), false},
+		{time.Date(2024, 8, 2, 0, 0, 0, 0, time.Local), false},
+		{time.Date(2024, 12, 24, 12, 0, 0, 0, time.Local), true},
+		{time.Date(2030, 12, 24, 12, 0, 0, 0, time.Local), true},
+		{time.Date(2030, 12, 25, 12, 0, 0, 0, time.Local), false},
+	} {
+		t.Log(tc)
+		clck.Set(tc.now)
+		assert.Equal(t, tc.active, lp.holidayModeActive())
+	}
+}
+
+func TestHolidayOverrides(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		setup  func(lp *Loadpoint)
+		charge bool
+	}{
+		{"holiday", func(lp *Loadpoint) {}, false},
+		{"remote start", func(lp *Loadpoint) { lp.remoteSession = "secret" }, true},
+		{"force charge", func(lp *Loadpoint) { lp.forceCharge = true }, true},
+	} {
+		t.Log(tc.name)
+
+		clck := clock.NewMock()
+		clck.Set(time.Date(2024, 12, 24, 12, 0, 0, 0, time.Local))
+
+		ctrl := gomock.NewController(t)
+		charger := api.NewMockCharger(ctrl)
+
+		lp := &Loadpoint{
+			log:           util.NewLogger("foo"),
+			bus:           evbus.New(),
+			clock:         clck,
+			charger:       charger,
+			chargeMeter:   &Null{}, // silence nil panics
+			chargeRater:   &Null{}, // silence nil panics
+			chargeTimer:   &Null{}, // silence nil panics
+			wakeUpTimer:   NewTimer(),
+			sessionEnergy: NewEnergyMetrics(),
+			minCurrent:    minA,
+			maxCurrent:    maxA,
+			status:        api.StatusB,
+			mode:          api.ModeNow,
+			Mode_:         api.ModeOff, // default mode
+			HolidayDates:  []string{"12-24"},
+		}
+
+		attachListeners(t, lp)
+		tc.setup(lp)
+
+		charger.EXPECT().Enabled().Return(lp.enabled, nil)
+		charger.EXPECT().Status().Return(api.StatusB, nil)
+		if tc.charge {
+			charger.EXPECT().MaxCurrent(int64(maxA)).Return(nil)
+		} else {
+			charger.EXPECT().Enable(false).Return(nil)
+		}
+		lp.Update(500, false, false, false, 0, nil, nil)
+
+		assert.Equal(t, tc.charge, lp.chargeCurrent == float64(maxA))
+	}
+}
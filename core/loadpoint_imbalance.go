@@ -0,0 +1,47 @@
+package core
+
+import (
+	"math"
+
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// phaseImbalance returns the maximum deviation of a phase current from the mean phase current
+func phaseImbalance(currents []float64) float64 {
+	if len(currents) == 0 {
+		return 0
+	}
+
+	var mean float64
+	for _, i := range currents {
+		mean += i
+	}
+	mean /= float64(len(currents))
+
+	var res float64
+	for _, i := range currents {
+		res = max(res, math.Abs(i-mean))
+	}
+
+	return res
+}
+
+// updatePhaseImbalance publishes the phase current imbalance and warns if it exceeds the limit.
+// Charging is not changed, reacting to an imbalance is left to the user.
+func (lp *Loadpoint) updatePhaseImbalance() {
+	limit := lp.MaxImbalance
+	if limit <= 0 {
+		limit = maxImbalance
+	}
+
+	imbalance := phaseImbalance(lp.chargeCurrents)
+	active := imbalance > limit
+
+	if active && !lp.phaseImbalance {
+		lp.log.WARN.Printf("phase imbalance: %.3gA exceeds %.3gA", imbalance, limit)
+	}
+	lp.phaseImbalance = active
+
+	lp.publish(keys.PhaseImbalance, imbalance)
+	lp.publish(keys.PhaseImbalanceActive, active)
+}
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhaseImbalance(t *testing.T) {
+	for _, tc := range []struct {
+		currents []float64
+		res      float64
+	}{
+		{nil, 0},
+		{[]float64{16, 16, 16}, 0},
+		{[]float64{16, 0, 0}, 32.0 / 3},
+		{[]float64{10, 12, 14}, 2},
+	} {
+		assert.InDelta(t, tc.res, phaseImbalance(tc.currents), 1e-9, tc.currents)
+	}
+}
+
+func TestUpdatePhaseImbalance(t *testing.T) {
+	for _, tc := range []struct {
+		max    float64
+		meter  *currentsStub
+		active bool
+	}{
+		{0, &currentsStub{16, 16, 12}, false},
+		{0, &currentsStub{16, 16, 8}, true},
+		{6, &currentsStub{16, 16, 8}, false},
+		{0, &currentsStub{16, 0, 0}, true},
+	} {
+		lp := &Loadpoint{
+			log:          util.NewLogger("foo"),
+			clock:        clock.NewMock(),
+			chargeMeter:  tc.meter,
+			MaxImbalance: tc.max,
+		}
+
+		lp.updateChargeCurrents()
+		assert.Equal(t, tc.active, lp.phaseImbalance, tc)
+	}
+}
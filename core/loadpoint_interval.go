@@ -0,0 +1,6 @@
+package core
+
+// minChargeIntervalActive returns true if charging must continue at min current until the minimum charge interval has passed
+func (lp *Loadpoint) minChargeIntervalActive() bool {
+	return lp.MinChargeInterval > 0 && lp.charging() && lp.clock.Since(lp.chargeStartedAt) < lp.MinChargeInterval
+}
@@ -0,0 +1,49 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMinChargeInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clck := clock.NewMock()
+	clck.Add(time.Hour) // avoid time.IsZero
+
+	Voltage = 230 // V
+
+	lp := &Loadpoint{
+		log:               util.NewLogger("foo"),
+		clock:             clck,
+		charger:           api.NewMockCharger(ctrl),
+		status:            api.StatusC,
+		enabled:           true,
+		phases:            1,
+		minCurrent:        minA,
+		maxCurrent:        maxA,
+		chargeCurrent:     minA,
+		chargeStartedAt:   clck.Now(),
+		MinChargeInterval: 15 * time.Minute,
+		Disable:           ThresholdConfig{Delay: time.Minute},
+	}
+
+	// transient pv dip
+	sitePower := 1000.0
+
+	// disable timer starts
+	assert.Equal(t, float64(minA), lp.pvMaxCurrent(api.ModePV, sitePower, false, false))
+
+	// disable timer elapsed within min charge interval
+	clck.Add(5 * time.Minute)
+	assert.Equal(t, float64(minA), lp.pvMaxCurrent(api.ModePV, sitePower, false, false))
+
+	// min charge interval elapsed
+	clck.Add(10 * time.Minute)
+	assert.Equal(t, 0.0, lp.pvMaxCurrent(api.ModePV, sitePower, false, false))
+}
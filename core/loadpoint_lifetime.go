@@ -0,0 +1,41 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// addLifetimeSolarEnergy accumulates the solar share of charged energy in kWh
+func (lp *Loadpoint) addLifetimeSolarEnergy(addedGreen float64) {
+	if addedGreen <= 0 {
+		return
+	}
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.lifetimeSolarEnergy += addedGreen
+	lp.publish(keys.LifetimeSolarEnergy, lp.lifetimeSolarEnergy)
+	lp.settings.SetFloat(keys.LifetimeSolarEnergy, lp.lifetimeSolarEnergy)
+}
+
+// annualSummary sends the annual summary notification once a new year has started
+func (lp *Loadpoint) annualSummary() {
+	now := lp.clock.Now()
+
+	// don't send summary on startup
+	if lp.annualSummaryYear == 0 {
+		lp.annualSummaryYear = now.Year()
+		return
+	}
+
+	if now.Year() == lp.annualSummaryYear || now.Before(time.Date(now.Year(), time.January, 1, 0, 1, 0, 0, now.Location())) {
+		return
+	}
+
+	lp.annualSummaryYear = now.Year()
+
+	lp.log.INFO.Printf("lifetime solar energy: %.1fkWh", lp.GetLifetimeSolarEnergy())
+	lp.pushEvent(evAnnualSummary)
+}
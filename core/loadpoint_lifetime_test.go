@@ -0,0 +1,91 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifetimeSolarEnergy(t *testing.T) {
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		sessionEnergy: NewEnergyMetrics(),
+	}
+
+	// 50% solar share
+	lp.sessionEnergy.SetEnvironment(0.5, nil, nil)
+	_, green := lp.sessionEnergy.Update(2)
+	lp.addLifetimeSolarEnergy(green)
+	assert.Equal(t, 1.0, lp.GetLifetimeSolarEnergy())
+
+	// no solar share
+	lp.sessionEnergy.SetEnvironment(0, nil, nil)
+	_, green = lp.sessionEnergy.Update(3)
+	lp.addLifetimeSolarEnergy(green)
+	assert.Equal(t, 1.0, lp.GetLifetimeSolarEnergy())
+
+	// full solar share
+	lp.sessionEnergy.SetEnvironment(1, nil, nil)
+	_, green = lp.sessionEnergy.Update(5)
+	lp.addLifetimeSolarEnergy(green)
+	assert.Equal(t, 3.0, lp.GetLifetimeSolarEnergy())
+
+	// ignore negative values
+	lp.addLifetimeSolarEnergy(-1)
+	assert.Equal(t, 3.0, lp.GetLifetimeSolarEnergy())
+
+	lp.ResetLifetimeSolarEnergy()
+	assert.Equal(t, 0.0, lp.GetLifetimeSolarEnergy())
+}
+
+func TestAnnualSummary(t *testing.T) {
+	clck := clock.NewMock()
+	clck.Set(time.Date(2023, time.December, 31, 23, 59, 0, 0, time.Local))
+
+	pushChan := make(chan push.Event, 1)
+
+	lp := &Loadpoint{
+		log:      util.NewLogger("foo"),
+		clock:    clck,
+		pushChan: pushChan,
+	}
+
+	expectEvent := func(expect bool) {
+		t.Helper()
+		select {
+		case ev := <-pushChan:
+			assert.True(t, expect, "unexpected event")
+			assert.Equal(t, evAnnualSummary, ev.Event)
+		default:
+			assert.False(t, expect, "missing event")
+		}
+	}
+
+	// startup
+	lp.annualSummary()
+	expectEvent(false)
+
+	// midnight
+	clck.Add(time.Minute)
+	lp.annualSummary()
+	expectEvent(false)
+
+	// 00:01
+	clck.Add(time.Minute)
+	lp.annualSummary()
+	expectEvent(true)
+
+	// once per year
+	clck.Add(time.Hour)
+	lp.annualSummary()
+	expectEvent(false)
+
+	// next year
+	clck.Set(time.Date(2025, time.January, 1, 0, 1, 0, 0, time.Local))
+	lp.annualSummary()
+	expectEvent(true)
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// chargerExStub adds milli-amp current control to a charger mock
+type chargerExStub struct {
+	*api.MockCharger
+	current float64
+}
+
+func (c *chargerExStub) MaxCurrentMillis(current float64) error {
+	c.current = current
+	return nil
+}
+
+func newChargerTestLoadpoint(clck *clock.Mock, charger api.Charger) *Loadpoint {
+	return &Loadpoint{
+		log:         util.NewLogger("foo"),
+		bus:         evbus.New(),
+		clock:       clck,
+		charger:     charger,
+		wakeUpTimer: NewTimer(),
+		minCurrent:  6,
+		maxCurrent:  16,
+	}
+}
+
+func TestSetLimit(t *testing.T) {
+	tc := []struct {
+		name              string
+		enabled           bool
+		chargeCurrent     float64
+		limit             float64
+		expect            func(*api.MockCharger)
+		expEnabled        bool
+		expChargeCurrent  float64
+		expSwitchedUpdate bool
+	}{
+		{"enable", false, 0, 10, func(c *api.MockCharger) {
+			c.EXPECT().MaxCurrent(int64(10)).Return(nil)
+			c.EXPECT().Enable(true).Return(nil)
+		}, true, 10, true},
+		{"unchanged", true, 10, 10, func(c *api.MockCharger) {}, true, 10, false},
+		{"increase current", true, 10, 16, func(c *api.MockCharger) {
+			c.EXPECT().MaxCurrent(int64(16)).Return(nil)
+		}, true, 16, false},
+		{"truncate current", true, 10, 12.7, func(c *api.MockCharger) {
+			c.EXPECT().MaxCurrent(int64(12)).Return(nil)
+		}, true, 12, false},
+		{"disable", true, 10, 0, func(c *api.MockCharger) {
+			c.EXPECT().Enable(false).Return(nil)
+		}, false, 10, true},
+		{"below min current disables", true, 10, 5, func(c *api.MockCharger) {
+			c.EXPECT().Enable(false).Return(nil)
+		}, false, 10, true},
+		{"already disabled", false, 10, 0, func(c *api.MockCharger) {}, false, 10, false},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			charger := api.NewMockCharger(ctrl)
+			clck := clock.NewMock()
+			clck.Add(time.Hour)
+
+			lp := newChargerTestLoadpoint(clck, charger)
+			lp.enabled = tc.enabled
+			lp.chargeCurrent = tc.chargeCurrent
+
+			tc.expect(charger)
+			require.NoError(t, lp.setLimit(tc.limit))
+
+			assert.Equal(t, tc.expEnabled, lp.enabled, "enabled")
+			assert.Equal(t, tc.expChargeCurrent, lp.chargeCurrent, "charge current")
+
+			if tc.expSwitchedUpdate {
+				assert.Equal(t, clck.Now(), lp.chargerSwitched, "charger switched")
+			} else {
+				assert.True(t, lp.chargerSwitched.IsZero(), "charger switched")
+			}
+		})
+	}
+}
+
+func TestSetLimitErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+	lp := newChargerTestLoadpoint(clock.NewMock(), charger)
+
+	// current error keeps state
+	charger.EXPECT().MaxCurrent(int64(10)).Return(errors.New("foo"))
+	assert.Error(t, lp.setLimit(10))
+	assert.Equal(t, 0.0, lp.chargeCurrent)
+	assert.False(t, lp.enabled)
+
+	// enable error keeps state
+	charger.EXPECT().MaxCurrent(int64(10)).Return(nil)
+	charger.EXPECT().Enable(true).Return(errors.New("foo"))
+	assert.Error(t, lp.setLimit(10))
+	assert.Equal(t, 10.0, lp.chargeCurrent)
+	assert.False(t, lp.enabled)
+	assert.True(t, lp.chargerSwitched.IsZero())
+}
+
+func TestSetLimitMillis(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := &chargerExStub{MockCharger: api.NewMockCharger(ctrl)}
+	lp := newChargerTestLoadpoint(clock.NewMock(), charger)
+
+	charger.EXPECT().Enable(true).Return(nil)
+	require.NoError(t, lp.setLimit(12.7))
+
+	assert.Equal(t, 12.7, charger.current)
+	assert.Equal(t, 12.7, lp.chargeCurrent)
+	assert.True(t, lp.enabled)
+}
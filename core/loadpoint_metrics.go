@@ -0,0 +1,18 @@
+package core
+
+import "github.com/evcc-io/evcc/core/metrics"
+
+// registerMetrics creates and registers the metrics collector if a metrics registerer is configured
+func (lp *Loadpoint) registerMetrics() {
+	if lp.metricsRegisterer == nil {
+		return
+	}
+
+	c := metrics.New(lp.Title())
+	if err := lp.metricsRegisterer.Register(c); err != nil {
+		lp.log.WARN.Printf("metrics: %v", err)
+		return
+	}
+
+	lp.metrics = c
+}
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// gatherValue returns the value of the named metric, optionally matching the given label value
+func gatherValue(t *testing.T, reg *prometheus.Registry, name, label, value string) float64 {
+	t.Helper()
+
+	mfs, err := reg.Gather()
+	require.NoError(t, err)
+
+	for _, mf := range mfs {
+		if mf.GetName() != name {
+			continue
+		}
+
+		for _, m := range mf.GetMetric() {
+			if label == "" {
+				return m.GetGauge().GetValue()
+			}
+
+			for _, l := range m.GetLabel() {
+				if l.GetName() == label && l.GetValue() == value {
+					return m.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+
+	require.Fail(t, "metric not found", name)
+	return 0
+}
+
+func TestLoadpointMetrics(t *testing.T) {
+	Voltage = 230 // V
+
+	clck := clock.NewMock()
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+	meter := api.NewMockMeter(ctrl)
+	reg := prometheus.NewRegistry()
+
+	lp := &Loadpoint{
+		log:               util.NewLogger("foo"),
+		bus:               evbus.New(),
+		clock:             clck,
+		charger:           charger,
+		chargeMeter:       meter,
+		chargeRater:       &Null{}, // silence nil panics
+		chargeTimer:       &Null{}, // silence nil panics
+		wakeUpTimer:       NewTimer(),
+		sessionEnergy:     NewEnergyMetrics(),
+		minCurrent:        minA,
+		maxCurrent:        maxA,
+		phases:            1,
+		status:            api.StatusC,
+		mode:              api.ModeNow,
+		metricsRegisterer: reg,
+	}
+
+	uiChan := make(chan util.Param, 1000)
+	_, pushChan, lpChan := createChannels(t)
+
+	charger.EXPECT().Enabled().Return(true, nil)
+	charger.EXPECT().MaxCurrent(int64(minA)).Return(nil)
+	lp.Prepare(context.Background(), uiChan, pushChan, lpChan)
+
+	meter.EXPECT().CurrentPower().Return(3680.0, nil)
+	lp.UpdateChargePower()
+
+	charger.EXPECT().Enabled().Return(true, nil)
+	charger.EXPECT().Status().Return(api.StatusC, nil)
+	charger.EXPECT().MaxCurrent(int64(maxA)).Return(nil)
+	lp.Update(0, false, false, false, 0, nil, nil)
+
+	// last published values
+	published := make(map[string]any)
+	for len(uiChan) > 0 {
+		p := <-uiChan
+		published[p.Key] = p.Val
+	}
+
+	assert.Equal(t, published[keys.ChargePower], gatherValue(t, reg, "evcc_charge_power_watts", "", ""))
+	assert.Equal(t, published[keys.ChargedEnergy], gatherValue(t, reg, "evcc_charged_energy_wh", "", ""))
+	assert.Equal(t, published[keys.ChargeDuration].(time.Duration).Seconds(), gatherValue(t, reg, "evcc_charge_duration_seconds", "", ""))
+	assert.Equal(t, true, published[keys.Charging])
+
+	assert.Equal(t, 0.0, gatherValue(t, reg, "evcc_charger_status", "status", "A"))
+	assert.Equal(t, 0.0, gatherValue(t, reg, "evcc_charger_status", "status", "B"))
+	assert.Equal(t, 1.0, gatherValue(t, reg, "evcc_charger_status", "status", "C"))
+}
@@ -0,0 +1,155 @@
+package core
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	mismatchLogFile     = "mismatch.log" // current mismatch log file name
+	mismatchLogMaxSize  = 10 << 20       // rotate current mismatch log at 10MB
+	mismatchLogInterval = time.Minute    // minimum interval between log entries of an ongoing mismatch
+	mismatchThreshold   = 2.0            // A, minimum logged current deviation
+)
+
+var (
+	mismatchLogsMu sync.Mutex
+	mismatchLogs   = make(map[string]*rotatingFile)
+)
+
+// sharedMismatchLog returns the current mismatch log at path shared by all loadpoints
+func sharedMismatchLog(path string) *rotatingFile {
+	mismatchLogsMu.Lock()
+	defer mismatchLogsMu.Unlock()
+
+	if f, ok := mismatchLogs[path]; ok {
+		return f
+	}
+
+	f := &rotatingFile{path: path, maxSize: mismatchLogMaxSize}
+	mismatchLogs[path] = f
+
+	return f
+}
+
+// currentMismatch is a current mismatch log entry
+type currentMismatch struct {
+	Timestamp time.Time `json:"ts"`
+	Loadpoint string    `json:"loadpoint"`
+	Commanded float64   `json:"commanded"`
+	Measured  float64   `json:"measured"`
+	Delta     float64   `json:"delta"`
+}
+
+// logCurrentMismatch logs deviations between commanded and measured charge current
+// when a mismatch starts and at most every mismatchLogInterval while it continues
+func (lp *Loadpoint) logCurrentMismatch() {
+	if lp.mismatchLog == nil {
+		return
+	}
+
+	command := lp.commandedCurrent
+	if !lp.enabled || !lp.charging() || command <= 0 || len(lp.chargeCurrents) == 0 {
+		lp.mismatchActive = false
+		return
+	}
+
+	measured := slices.Max(lp.chargeCurrents)
+	delta := command - measured
+	if math.Abs(delta) <= mismatchThreshold {
+		lp.mismatchActive = false
+		return
+	}
+
+	now := lp.clock.Now()
+	if lp.mismatchActive && now.Sub(lp.mismatchLogged) < mismatchLogInterval {
+		return
+	}
+
+	lp.mismatchActive = true
+	lp.mismatchLogged = now
+
+	b, err := json.Marshal(currentMismatch{
+		Timestamp: now,
+		Loadpoint: lp.Title(),
+		Commanded: command,
+		Measured:  measured,
+		Delta:     delta,
+	})
+	if err != nil {
+		lp.log.ERROR.Printf("current mismatch: %v", err)
+		return
+	}
+
+	if _, err := lp.mismatchLog.Write(append(b, '\n')); err != nil {
+		lp.log.ERROR.Printf("current mismatch: %v", err)
+	}
+}
+
+// rotatingFile is an append-only file which is rotated when exceeding maxSize
+type rotatingFile struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+	file    *os.File
+	size    int64
+}
+
+// open opens the file for appending
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	fi, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	f.file, f.size = file, fi.Size()
+
+	return nil
+}
+
+// rotate moves the current file to a single backup and starts a new file
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+
+	if err := os.Rename(f.path, f.path+".1"); err != nil {
+		return err
+	}
+
+	return f.open()
+}
+
+// Write implements io.Writer
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+
+	return n, err
+}
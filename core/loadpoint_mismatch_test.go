@@ -0,0 +1,93 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrentMismatchLog(t *testing.T) {
+	clck := clock.NewMock()
+	clck.Set(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	path := filepath.Join(t.TempDir(), mismatchLogFile)
+
+	lp := &Loadpoint{
+		log:                util.NewLogger("foo"),
+		clock:              clck,
+		Title_:             "garage",
+		status:             api.StatusC,
+		enabled:            true,
+		CurrentMismatchLog: true,
+		mismatchLog:        &rotatingFile{path: path, maxSize: mismatchLogMaxSize},
+	}
+
+	for _, tc := range []struct {
+		commanded float64
+		measured  []float64
+		elapsed   time.Duration
+	}{
+		{14, []float64{9.5, 9.8, 10}, 0},                 // logged
+		{14, []float64{12.5, 12, 12}, 0},                 // within threshold
+		{6, []float64{8.5, 0, 0}, 0},                     // logged
+		{6, []float64{8.5, 0, 0}, 30 * time.Second},      // ongoing, rate limited
+		{6, []float64{9, 0, 0}, mismatchLogInterval / 2}, // ongoing, logged again
+	} {
+		clck.Add(tc.elapsed)
+		lp.commandedCurrent = tc.commanded
+		lp.chargeCurrents = tc.measured
+		lp.logCurrentMismatch()
+	}
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var res []map[string]any
+	for scanner := bufio.NewScanner(f); scanner.Scan(); {
+		var m map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &m))
+		res = append(res, m)
+	}
+
+	require.Len(t, res, 3)
+	assert.Equal(t, map[string]any{
+		"ts":        "2024-03-01T12:00:00Z",
+		"loadpoint": "garage",
+		"commanded": 14.0,
+		"measured":  10.0,
+		"delta":     4.0,
+	}, res[0])
+	assert.Equal(t, -2.5, res[1]["delta"])
+	assert.Equal(t, -3.0, res[2]["delta"])
+
+	// loadpoints share the log per path
+	assert.Same(t, sharedMismatchLog(path), sharedMismatchLog(path))
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), mismatchLogFile)
+	f := &rotatingFile{path: path, maxSize: 12}
+
+	for _, s := range []string{"12345\n", "1234\n", "abc\n"} {
+		_, err := f.Write([]byte(s))
+		require.NoError(t, err)
+	}
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "abc\n", string(b))
+
+	b, err = os.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Equal(t, "12345\n1234\n", string(b))
+}
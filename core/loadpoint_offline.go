@@ -0,0 +1,37 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// setOfflineFallbackActive sets and publishes the offline fallback state
+func (lp *Loadpoint) setOfflineFallbackActive(active bool) {
+	if active != lp.offlineFallbackActive {
+		if active {
+			lp.log.WARN.Printf("site meter offline: fallback to %dA", lp.OfflineFallbackCurrent)
+		} else {
+			lp.log.INFO.Println("site meter recovered: fallback ended")
+		}
+	}
+
+	lp.offlineFallbackActive = active
+	lp.publish(keys.MeterOfflineFallbackActive, active)
+}
+
+// meterOfflineFallback applies the offline fallback current once the site meter has been unavailable for the timeout.
+// Charge mode off is respected.
+func (lp *Loadpoint) meterOfflineFallback(offline time.Duration) {
+	active := lp.OfflineFallbackTimeout > 0 && offline >= lp.OfflineFallbackTimeout &&
+		lp.connected() && lp.GetMode() != api.ModeOff
+
+	lp.setOfflineFallbackActive(active)
+
+	if active {
+		if err := lp.setLimit(min(float64(lp.OfflineFallbackCurrent), lp.effectiveMaxCurrent())); err != nil {
+			lp.log.ERROR.Printf("offline fallback: %v", err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMeterOfflineFallback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+
+	lp := &Loadpoint{
+		log:                    util.NewLogger("foo"),
+		bus:                    evbus.New(),
+		clock:                  clock.NewMock(),
+		charger:                charger,
+		wakeUpTimer:            NewTimer(),
+		status:                 api.StatusC,
+		mode:                   api.ModePV,
+		enabled:                true,
+		minCurrent:             6,
+		maxCurrent:             16,
+		chargeCurrent:          16,
+		OfflineFallbackCurrent: 8,
+		OfflineFallbackTimeout: 5 * time.Minute,
+	}
+
+	// outage shorter than timeout
+	lp.meterOfflineFallback(time.Minute)
+	assert.False(t, lp.offlineFallbackActive)
+
+	// fallback current applied regardless of pv mode
+	charger.EXPECT().MaxCurrent(int64(8)).Return(nil)
+	lp.meterOfflineFallback(5 * time.Minute)
+	assert.True(t, lp.offlineFallbackActive)
+	assert.Equal(t, 8.0, lp.chargeCurrent)
+
+	// meter recovered
+	lp.meterOfflineFallback(0)
+	assert.False(t, lp.offlineFallbackActive)
+
+	// fallback current capped at effective max current
+	lp.OfflineFallbackCurrent = 20
+	lp.setAllocatedCurrent(10, true)
+	charger.EXPECT().MaxCurrent(int64(10)).Return(nil)
+	lp.meterOfflineFallback(5 * time.Minute)
+	assert.Equal(t, 10.0, lp.chargeCurrent)
+	lp.setAllocatedCurrent(0, false)
+
+	// zero fallback current disables charging
+	lp.OfflineFallbackCurrent = 0
+	charger.EXPECT().Enable(false).Return(nil)
+	lp.meterOfflineFallback(10 * time.Minute)
+	assert.False(t, lp.enabled)
+
+	// mode off is respected
+	lp.mode = api.ModeOff
+	lp.meterOfflineFallback(10 * time.Minute)
+	assert.False(t, lp.offlineFallbackActive)
+}
+
+func TestSitePowerOffline(t *testing.T) {
+	site := new(Site)
+	now := time.Now()
+
+	assert.Equal(t, time.Duration(0), site.sitePowerOffline(now))
+	assert.Equal(t, time.Minute, site.sitePowerOffline(now.Add(time.Minute)))
+
+	site.sitePowerFailed = time.Time{}
+	assert.Equal(t, time.Duration(0), site.sitePowerOffline(now.Add(2*time.Minute)))
+}
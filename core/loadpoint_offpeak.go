@@ -0,0 +1,36 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// offPeakActive returns true if the current time is within the configured off-peak window
+func (lp *Loadpoint) offPeakActive() bool {
+	if lp.OffPeakStart == "" && lp.OffPeakEnd == "" {
+		return false
+	}
+
+	now := lp.clock.Now()
+
+	start, end, err := dailyWindow(now, lp.OffPeakStart, lp.OffPeakEnd)
+	if err != nil {
+		lp.log.ERROR.Printf("off-peak window: %v", err)
+		return false
+	}
+
+	return !now.Before(start) && now.Before(end)
+}
+
+// offPeakMode returns the charge mode effective in offpeak mode:
+// immediate charging within the off-peak window, pv charging otherwise
+func (lp *Loadpoint) offPeakMode() api.ChargeMode {
+	res := lp.offPeakActive()
+	lp.publish(keys.OffPeakActive, res)
+
+	if res {
+		return api.ModeNow
+	}
+
+	return api.ModePV
+}
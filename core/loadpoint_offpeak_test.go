@@ -0,0 +1,51 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOffPeakMode(t *testing.T) {
+	clck := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:          util.NewLogger("foo"),
+		clock:        clck,
+		OffPeakStart: "23:00",
+		OffPeakEnd:   "07:00",
+	}
+
+	for _, tc := range []struct {
+		now  time.Time
+		mode api.ChargeMode
+	}{
+		{time.Date(2024, 8, 1, 22, 59, 0, 0, time.Local), api.ModePV},
+		{time.Date(2024, 8, 1, 23, 0, 0, 0, time.Local), api.ModeNow},
+		{time.Date(2024, 8, 2, 0, 0, 0, 0, time.Local), api.ModeNow},
+		{time.Date(2024, 8, 2, 6, 59, 0, 0, time.Local), api.ModeNow},
+		{time.Date(2024, 8, 2, 7, 0, 0, 0, time.Local), api.ModePV},
+		{time.Date(2024, 8, 2, 12, 0, 0, 0, time.Local), api.ModePV},
+	} {
+		t.Log(tc)
+		clck.Set(tc.now)
+		assert.Equal(t, tc.mode, lp.offPeakMode())
+	}
+
+	// same day window
+	lp.OffPeakStart, lp.OffPeakEnd = "01:00", "05:00"
+
+	clck.Set(time.Date(2024, 8, 2, 0, 30, 0, 0, time.Local))
+	assert.Equal(t, api.ModePV, lp.offPeakMode())
+
+	clck.Set(time.Date(2024, 8, 2, 3, 0, 0, 0, time.Local))
+	assert.Equal(t, api.ModeNow, lp.offPeakMode())
+
+	// no window configured
+	lp.OffPeakStart, lp.OffPeakEnd = "", ""
+	assert.Equal(t, api.ModePV, lp.offPeakMode())
+}
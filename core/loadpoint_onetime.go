@@ -0,0 +1,66 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// SetOneTimeSchedule sets a plan departure and soc target that takes precedence over the vehicle plan
+// for a single vehicle session. Zero departure removes the one-time schedule.
+func (lp *Loadpoint) SetOneTimeSchedule(departure time.Time, socTarget float64) error {
+	lp.Lock()
+	defer lp.Unlock()
+
+	if departure.IsZero() {
+		lp.setOneTimeSchedule(time.Time{}, 0)
+		lp.requestUpdate()
+		return nil
+	}
+
+	if departure.Before(lp.clock.Now()) {
+		return errors.New("timestamp is in the past")
+	}
+
+	if socTarget <= 0 || socTarget > 100 {
+		return fmt.Errorf("invalid soc target: %.0f%%", socTarget)
+	}
+
+	lp.log.DEBUG.Printf("set one-time schedule: %.0f%% @ %v", socTarget, departure.Round(time.Second).Local())
+
+	lp.setOneTimeSchedule(departure, int(math.Round(socTarget)))
+	lp.requestUpdate()
+
+	return nil
+}
+
+// setOneTimeSchedule updates and publishes the one-time schedule (no mutex)
+func (lp *Loadpoint) setOneTimeSchedule(departure time.Time, soc int) {
+	lp.oneTimeDeparture = departure
+	lp.oneTimeSoc = soc
+
+	lp.publish(keys.OneTimeScheduleActive, !departure.IsZero())
+	lp.publish(keys.OneTimeDeparture, departure)
+}
+
+// getOneTimeSchedule returns the one-time schedule departure and soc target
+func (lp *Loadpoint) getOneTimeSchedule() (time.Time, int) {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.oneTimeDeparture, lp.oneTimeSoc
+}
+
+// clearOneTimeSchedule removes the one-time schedule, reverting to the vehicle plan
+func (lp *Loadpoint) clearOneTimeSchedule() {
+	lp.Lock()
+	defer lp.Unlock()
+
+	if !lp.oneTimeDeparture.IsZero() {
+		lp.log.DEBUG.Println("one-time schedule removed")
+	}
+
+	lp.setOneTimeSchedule(time.Time{}, 0)
+}
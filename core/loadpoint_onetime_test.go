@@ -0,0 +1,62 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOneTimeSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clck := clock.NewMock()
+
+	vehicle := api.NewMockVehicle(ctrl)
+	vehicle.EXPECT().Capacity().Return(50.0).AnyTimes()
+	vehicle.EXPECT().Features().AnyTimes()
+
+	lp := &Loadpoint{
+		log:        util.NewLogger("foo"),
+		clock:      clck,
+		vehicle:    vehicle,
+		vehicleSoc: 40,
+	}
+
+	// configured schedule
+	assert.True(t, lp.EffectivePlanTime().IsZero())
+
+	assert.Error(t, lp.SetOneTimeSchedule(clck.Now().Add(-time.Hour), 80), "past")
+	assert.Error(t, lp.SetOneTimeSchedule(clck.Now().Add(time.Hour), 0), "invalid soc")
+	assert.Error(t, lp.SetOneTimeSchedule(clck.Now().Add(time.Hour), 101), "invalid soc")
+
+	departure := clck.Now().Add(12 * time.Hour)
+	require.NoError(t, lp.SetOneTimeSchedule(departure, 80))
+
+	// one-time schedule takes precedence
+	assert.Equal(t, departure, lp.EffectivePlanTime())
+	assert.Equal(t, 80, lp.EffectivePlanSoc())
+	goal, socBased := lp.GetPlanGoal()
+	assert.Equal(t, 80.0, goal)
+	assert.True(t, socBased)
+
+	// plan completion only removes the one-time schedule
+	lp.deletePlan()
+	assert.True(t, lp.EffectivePlanTime().IsZero())
+
+	// vehicle disconnect reverts to configured schedule for the next session
+	require.NoError(t, lp.SetOneTimeSchedule(departure, 90))
+	assert.Equal(t, 90, lp.EffectivePlanSoc())
+	lp.clearOneTimeSchedule()
+	assert.True(t, lp.EffectivePlanTime().IsZero())
+	assert.Equal(t, 0, lp.EffectivePlanSoc())
+
+	// explicit removal
+	require.NoError(t, lp.SetOneTimeSchedule(departure, 80))
+	require.NoError(t, lp.SetOneTimeSchedule(time.Time{}, 0))
+	assert.True(t, lp.EffectivePlanTime().IsZero())
+}
@@ -1,10 +1,16 @@
 package core
 
 import (
+	"math"
+	"time"
+
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/keys"
 )
 
+// inferredPhasesReadings is the number of consistent power readings required for phase inference
+const inferredPhasesReadings = 5
+
 // setConfiguredPhases sets the default phase configuration
 func (lp *Loadpoint) setConfiguredPhases(phases int) {
 	lp.configuredPhases = phases
@@ -114,3 +120,121 @@ func (lp *Loadpoint) hasPhaseSwitching() bool {
 	_, ok := lp.charger.(api.PhaseSwitcher)
 	return ok
 }
+
+// setPhaseDowngrade updates the automatic 1p downgrade state
+func (lp *Loadpoint) setPhaseDowngrade(active bool) {
+	if lp.phaseDowngrade != active {
+		lp.phaseDowngrade = active
+		lp.publish(keys.PhaseDowngradeActive, active)
+	}
+}
+
+// socPhaseHysteresis is the soc margin below SocPhaseThreshold required for switching back to 3p
+const socPhaseHysteresis = 5
+
+// socScalePhases switches to 1p above the soc phase threshold and holds 1p within the hysteresis.
+// It returns true if phase selection is determined by vehicle soc.
+func (lp *Loadpoint) socScalePhases() bool {
+	threshold := lp.SocPhaseThreshold
+	if threshold <= 0 || lp.vehicleSoc == 0 {
+		lp.setSocPhaseSwitch(false)
+		return false
+	}
+
+	switch phases := lp.GetPhases(); {
+	case lp.vehicleSoc > threshold && phases != 1:
+		// protect contactor from rapid switching
+		if lp.phaseSwitchCooldownRemaining() > 0 {
+			return false
+		}
+
+		lp.log.DEBUG.Printf("soc %.0f%% > %.0f%%: switching to 1p", lp.vehicleSoc, threshold)
+
+		if err := lp.scalePhases(1); err != nil {
+			lp.log.ERROR.Println(err)
+			return false
+		}
+
+		lp.setSocPhaseSwitch(true)
+		return true
+
+	case phases == 1 && lp.socPhaseSwitch && lp.vehicleSoc >= threshold-socPhaseHysteresis:
+		// hold 1p until soc drops below hysteresis
+		return true
+	}
+
+	lp.setSocPhaseSwitch(false)
+
+	return false
+}
+
+// setSocPhaseSwitch updates the soc triggered 1p state
+func (lp *Loadpoint) setSocPhaseSwitch(active bool) {
+	if lp.socPhaseSwitch != active {
+		lp.socPhaseSwitch = active
+		lp.publish(keys.SocPhaseSwitchActive, active)
+	}
+}
+
+// phaseSwitchCooldownRemaining returns the remaining duration until pv phase switching is allowed again
+func (lp *Loadpoint) phaseSwitchCooldownRemaining() time.Duration {
+	if lp.phasesSwitched.IsZero() {
+		return 0
+	}
+
+	return max(0, lp.PhaseSwitchCooldownDuration-lp.clock.Since(lp.phasesSwitched))
+}
+
+// powerPhasesTolerance is the deviation of charge power from a phase multiple accepted for phase inference,
+// relative to the single phase power at the current limit
+const powerPhasesTolerance = 0.1
+
+// powerPhases returns the number of phases matching charge power at the given current and voltage.
+// Readings not close to a phase multiple, e.g. if the vehicle draws less than the current limit, are rejected.
+func powerPhases(power, current, voltage float64) (int, bool) {
+	ratio := power / (current * voltage)
+	phases := int(math.Round(ratio))
+
+	if phases < 1 || phases > 3 || math.Abs(ratio-float64(phases)) > powerPhasesTolerance {
+		return 0, false
+	}
+
+	return phases, true
+}
+
+// inferPhasesFromPower updates measured phases from charge power after consistent readings.
+// Only used if the charger does not provide phase currents.
+func (lp *Loadpoint) inferPhasesFromPower() {
+	if !lp.AutoPhaseDetectFromPower || lp.chargeCurrents != nil {
+		return
+	}
+
+	if !lp.charging() || !lp.phaseSwitchCompleted() || lp.chargeCurrent <= 0 || lp.chargePower <= 0 {
+		lp.inferredPhasesCount = 0
+		return
+	}
+
+	phases, ok := powerPhases(lp.chargePower, lp.chargeCurrent, lp.effectiveVoltage())
+	if !ok {
+		return
+	}
+
+	lp.publish(keys.PowerInferredPhases, phases)
+
+	if phases != lp.inferredPhases {
+		lp.inferredPhases = phases
+		lp.inferredPhasesCount = 0
+	}
+
+	lp.inferredPhasesCount++
+	if lp.inferredPhasesCount < inferredPhasesReadings || phases == lp.getMeasuredPhases() {
+		return
+	}
+
+	lp.Lock()
+	lp.measuredPhases = phases
+	lp.Unlock()
+
+	lp.log.DEBUG.Printf("detected active phases from power: %dp", phases)
+	lp.publish(keys.PhasesActive, phases)
+}
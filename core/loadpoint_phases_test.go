@@ -9,6 +9,7 @@ import (
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
 
@@ -446,3 +447,320 @@ func TestScalePhasesIfAvailable(t *testing.T) {
 		ctrl.Finish()
 	}
 }
+
+func TestPvScalePhasesAutoDowngrade(t *testing.T) {
+	Voltage = 230 // V
+
+	tc := []struct {
+		desc       string
+		downgrade  bool
+		sitePower  float64
+		toPhases   int
+		expDowngr  bool
+		expScaled  bool
+		expCurrent float64
+	}{
+		{"without downgrade timer starts", false, 3 * Voltage * minA * 0.5, 3, false, false, 0},
+		{"1p sufficient", true, 3*Voltage*minA - 8*Voltage, 1, true, true, 8},
+		{"1p insufficient", true, 3*Voltage*minA - 4*Voltage, 3, false, false, 0},
+	}
+
+	for _, tc := range tc {
+		t.Log(tc.desc)
+
+		ctrl := gomock.NewController(t)
+		charger := &struct {
+			*api.MockCharger
+			*api.MockPhaseSwitcher
+		}{
+			api.NewMockCharger(ctrl),
+			api.NewMockPhaseSwitcher(ctrl),
+		}
+
+		clock := clock.NewMock()
+		clock.Add(time.Hour) // avoid time.IsZero
+
+		lp := &Loadpoint{
+			log:                util.NewLogger("foo"),
+			clock:              clock,
+			charger:            charger,
+			minCurrent:         minA,
+			maxCurrent:         maxA,
+			phases:             3,
+			measuredPhases:     3,
+			status:             api.StatusC,
+			enabled:            true,
+			chargePower:        3 * Voltage * minA,
+			PhaseAutoDowngrade: tc.downgrade,
+			Disable:            ThresholdConfig{Delay: time.Minute},
+		}
+
+		if tc.expScaled {
+			charger.MockPhaseSwitcher.EXPECT().Phases1p3p(1).Return(nil)
+		}
+
+		assert.Equal(t, tc.expScaled, lp.pvScalePhases(tc.sitePower, minA, maxA), tc.desc)
+		assert.Equal(t, tc.toPhases, lp.phases, tc.desc)
+		assert.Equal(t, tc.expDowngr, lp.phaseDowngrade, tc.desc)
+
+		if tc.expScaled {
+			assert.Equal(t, tc.expCurrent, lp.powerToCurrent(lp.chargePower-tc.sitePower, lp.ActivePhases()), tc.desc)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestSocScalePhases(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := &struct {
+		*api.MockCharger
+		*api.MockPhaseSwitcher
+	}{
+		api.NewMockCharger(ctrl),
+		api.NewMockPhaseSwitcher(ctrl),
+	}
+
+	clock := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:               util.NewLogger("foo"),
+		clock:             clock,
+		charger:           charger,
+		phases:            3,
+		SocPhaseThreshold: 80,
+	}
+
+	// below threshold: pv phase scaling
+	lp.vehicleSoc = 70
+	assert.False(t, lp.socScalePhases())
+
+	// above threshold: switch to 1p
+	lp.vehicleSoc = 81
+	charger.MockPhaseSwitcher.EXPECT().Phases1p3p(1).Return(nil)
+	assert.True(t, lp.socScalePhases())
+	assert.Equal(t, 1, lp.phases)
+	assert.True(t, lp.socPhaseSwitch)
+
+	// within hysteresis: hold 1p without switching
+	for _, soc := range []float64{82, 79, 76, 75, 78} {
+		lp.vehicleSoc = soc
+		assert.True(t, lp.socScalePhases(), soc)
+		assert.Equal(t, 1, lp.phases)
+	}
+
+	// below hysteresis: release to pv phase scaling
+	lp.vehicleSoc = 74
+	assert.False(t, lp.socScalePhases())
+	assert.False(t, lp.socPhaseSwitch)
+
+	// 1p from pv scaling is not held
+	lp.vehicleSoc = 77
+	assert.False(t, lp.socScalePhases())
+}
+
+func TestSocScalePhasesCooldown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := &struct {
+		*api.MockCharger
+		*api.MockPhaseSwitcher
+	}{
+		api.NewMockCharger(ctrl),
+		api.NewMockPhaseSwitcher(ctrl),
+	}
+
+	clock := clock.NewMock()
+	clock.Add(time.Hour) // avoid time.IsZero
+
+	lp := &Loadpoint{
+		log:                         util.NewLogger("foo"),
+		clock:                       clock,
+		charger:                     charger,
+		phases:                      3,
+		vehicleSoc:                  81,
+		SocPhaseThreshold:           80,
+		phasesSwitched:              clock.Now(),
+		PhaseSwitchCooldownDuration: 10 * time.Minute,
+	}
+
+	// recently switched by pv scaling
+	clock.Add(time.Minute)
+	assert.False(t, lp.socScalePhases())
+	assert.Equal(t, 3, lp.phases)
+	assert.False(t, lp.socPhaseSwitch)
+
+	// cooldown elapsed
+	clock.Add(9 * time.Minute)
+	charger.MockPhaseSwitcher.EXPECT().Phases1p3p(1).Return(nil)
+	assert.True(t, lp.socScalePhases())
+	assert.Equal(t, 1, lp.phases)
+}
+
+func TestPvScalePhasesCooldown(t *testing.T) {
+	Voltage = 230 // V
+
+	ctrl := gomock.NewController(t)
+	charger := &struct {
+		*api.MockCharger
+		*api.MockPhaseSwitcher
+	}{
+		api.NewMockCharger(ctrl),
+		api.NewMockPhaseSwitcher(ctrl),
+	}
+
+	clock := clock.NewMock()
+	clock.Add(time.Hour) // avoid time.IsZero
+
+	lp := &Loadpoint{
+		log:                         util.NewLogger("foo"),
+		clock:                       clock,
+		charger:                     charger,
+		minCurrent:                  minA,
+		maxCurrent:                  maxA,
+		phases:                      1,
+		status:                      api.StatusC,
+		enabled:                     true,
+		chargePower:                 Voltage * minA,
+		phasesSwitched:              clock.Now(),
+		PhaseSwitchCooldownDuration: 10 * time.Minute,
+	}
+
+	// enough power for 3p
+	sitePower := -3 * Voltage * maxA
+
+	// recently switched
+	assert.False(t, lp.pvScalePhases(sitePower, minA, maxA))
+	assert.Equal(t, 10*time.Minute, lp.phaseSwitchCooldownRemaining())
+
+	clock.Add(5 * time.Minute)
+	assert.False(t, lp.pvScalePhases(sitePower, minA, maxA))
+	assert.Equal(t, 1, lp.phases)
+
+	// cooldown elapsed
+	clock.Add(5 * time.Minute)
+	charger.MockPhaseSwitcher.EXPECT().Phases1p3p(3).Return(nil)
+	assert.True(t, lp.pvScalePhases(sitePower, minA, maxA))
+	assert.Equal(t, 3, lp.phases)
+
+	// switching back is blocked again
+	lp.chargePower = 3 * Voltage * minA
+	assert.False(t, lp.pvScalePhases(3*Voltage*minA, minA, maxA))
+	assert.Equal(t, 3, lp.phases)
+	assert.Equal(t, 10*time.Minute, lp.phaseSwitchCooldownRemaining())
+}
+
+func TestPowerPhases(t *testing.T) {
+	Voltage = 230 // V
+
+	tc := []struct {
+		power, current float64
+		phases         int
+		ok             bool
+	}{
+		{1380, 6, 1, true},
+		{4140, 6, 3, true},
+		{3500, 16, 1, true},
+		{11040, 16, 3, true},
+		{7000, 16, 2, true},
+		{6900, 16, 0, false}, // 3p vehicle drawing 10A below limit
+		{3300, 16, 0, false}, // below limit
+		{100, 16, 0, false},
+		{20000, 16, 0, false},
+	}
+
+	for _, tc := range tc {
+		t.Logf("%+v", tc)
+		phases, ok := powerPhases(tc.power, tc.current, Voltage)
+		assert.Equal(t, tc.ok, ok)
+		assert.Equal(t, tc.phases, phases)
+	}
+}
+
+func TestInferPhasesFromPower(t *testing.T) {
+	Voltage = 230 // V
+
+	lp := &Loadpoint{
+		log:                      util.NewLogger("foo"),
+		status:                   api.StatusC,
+		chargeCurrent:            6,
+		chargePower:              1380,
+		AutoPhaseDetectFromPower: true,
+	}
+
+	// 1p after consistent readings
+	for range inferredPhasesReadings - 1 {
+		lp.inferPhasesFromPower()
+	}
+	assert.Equal(t, 0, lp.measuredPhases)
+
+	lp.inferPhasesFromPower()
+	assert.Equal(t, 1, lp.measuredPhases)
+
+	// inconsistent reading restarts counting
+	lp.chargePower = 4140
+	for range inferredPhasesReadings - 1 {
+		lp.inferPhasesFromPower()
+	}
+	lp.chargePower = 1380
+	lp.inferPhasesFromPower()
+	lp.chargePower = 4140
+	for range inferredPhasesReadings - 1 {
+		lp.inferPhasesFromPower()
+	}
+	assert.Equal(t, 1, lp.measuredPhases)
+
+	lp.inferPhasesFromPower()
+	assert.Equal(t, 3, lp.measuredPhases)
+
+	// vehicle drawing below the current limit is ignored
+	lp.chargeCurrent = 16
+	lp.chargePower = 3 * Voltage * 10
+	for range 2 * inferredPhasesReadings {
+		lp.inferPhasesFromPower()
+	}
+	assert.Equal(t, 3, lp.measuredPhases)
+
+	// measured phase currents take precedence
+	lp.measuredPhases = 2
+	lp.chargeCurrents = []float64{6, 6, 0}
+	for range inferredPhasesReadings {
+		lp.inferPhasesFromPower()
+	}
+	assert.Equal(t, 2, lp.measuredPhases)
+}
+
+// currentsStub is a charge meter returning fixed phase currents
+type currentsStub struct {
+	l1, l2, l3 float64
+}
+
+func (m *currentsStub) CurrentPower() (float64, error) {
+	return 0, nil
+}
+
+func (m *currentsStub) Currents() (float64, float64, float64, error) {
+	return m.l1, m.l2, m.l3, nil
+}
+
+func TestMinActiveCurrent(t *testing.T) {
+	for _, tc := range []struct {
+		minActive float64
+		phases    int
+	}{
+		{0, 2},   // default 1A
+		{0.5, 3}, // noise above threshold
+		{2, 1},
+	} {
+		t.Log(tc)
+
+		lp := &Loadpoint{
+			log:              util.NewLogger("foo"),
+			status:           api.StatusC,
+			chargeMeter:      &currentsStub{10, 1.5, 0.8},
+			MinActiveCurrent: tc.minActive,
+		}
+
+		lp.updateChargeCurrents()
+		assert.Equal(t, tc.phases, lp.measuredPhases)
+	}
+}
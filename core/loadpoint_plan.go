@@ -28,9 +28,11 @@ func (lp *Loadpoint) setPlanActive(active bool) {
 	}
 }
 
-// deletePlan deletes the charging plan, either loadpoint or vehicle
+// deletePlan deletes the charging plan, either one-time, loadpoint or vehicle
 func (lp *Loadpoint) deletePlan() {
-	if !lp.socBasedPlanning() {
+	if ts, _ := lp.getOneTimeSchedule(); !ts.IsZero() && lp.socBasedPlanning() {
+		lp.clearOneTimeSchedule()
+	} else if !lp.socBasedPlanning() {
 		lp.setPlanEnergy(time.Time{}, 0)
 	} else if v := lp.GetVehicle(); v != nil {
 		vehicle.Settings(lp.log, v).SetPlanSoc(time.Time{}, 0)
@@ -64,12 +66,11 @@ func (lp *Loadpoint) GetPlanGoal() (float64, bool) {
 	defer lp.RUnlock()
 
 	if lp.socBasedPlanning() {
-		_, soc := vehicle.Settings(lp.log, lp.GetVehicle()).GetPlanSoc()
+		_, soc := lp.vehiclePlanSoc()
 		return float64(soc), true
 	}
 
-	_, limit := lp.GetPlanEnergy()
-	return limit, false
+	return lp.planEnergy, false
 }
 
 // GetPlan creates a charging plan for given time and duration
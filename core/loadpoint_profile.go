@@ -0,0 +1,58 @@
+package core
+
+import (
+	"errors"
+	"time"
+)
+
+// validate checks the entry's time window and currents
+func (e ProfileEntry) validate() error {
+	if _, _, err := dailyWindow(time.Now(), e.Start, e.End); err != nil {
+		return err
+	}
+
+	if e.MinCurrent < 0 || e.MaxCurrent < 0 {
+		return errors.New("negative current")
+	}
+
+	if e.MinCurrent > 0 && e.MaxCurrent > 0 && e.MinCurrent > e.MaxCurrent {
+		return errors.New("min current above max current")
+	}
+
+	return nil
+}
+
+// activeProfileEntry returns the charge profile entry active at the given time
+func (lp *Loadpoint) activeProfileEntry(now time.Time) (ProfileEntry, bool) {
+	for _, entry := range lp.ChargeProfile {
+		if start, end, err := dailyWindow(now, entry.Start, entry.End); err == nil && !now.Before(start) && now.Before(end) {
+			return entry, true
+		}
+	}
+
+	return ProfileEntry{}, false
+}
+
+// profileCurrents returns the loadpoint min and max current, overridden by the active charge profile entry
+func (lp *Loadpoint) profileCurrents() (float64, float64) {
+	minCurrent, maxCurrent := lp.GetMinCurrent(), lp.GetMaxCurrent()
+
+	if len(lp.ChargeProfile) == 0 {
+		return minCurrent, maxCurrent
+	}
+
+	entry, ok := lp.activeProfileEntry(lp.clock.Now())
+	if !ok {
+		return minCurrent, maxCurrent
+	}
+
+	if entry.MinCurrent > 0 {
+		minCurrent = float64(entry.MinCurrent)
+	}
+	if entry.MaxCurrent > 0 {
+		maxCurrent = float64(entry.MaxCurrent)
+	}
+
+	// profile max current may be below loadpoint min current
+	return min(minCurrent, maxCurrent), maxCurrent
+}
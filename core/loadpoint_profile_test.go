@@ -0,0 +1,56 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileEntryValidate(t *testing.T) {
+	assert.NoError(t, ProfileEntry{Start: "22:00", End: "06:00", MaxCurrent: 10}.validate())
+	assert.Error(t, ProfileEntry{Start: "22", End: "06:00"}.validate())
+	assert.Error(t, ProfileEntry{Start: "22:00", End: "06:00", MinCurrent: 10, MaxCurrent: 8}.validate())
+	assert.Error(t, ProfileEntry{Start: "22:00", End: "06:00", MaxCurrent: -1}.validate())
+}
+
+func TestProfileCurrents(t *testing.T) {
+	clck := clock.NewMock()
+	now := time.Now()
+	clck.Set(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local))
+
+	lp := &Loadpoint{
+		log:        util.NewLogger("foo"),
+		clock:      clck,
+		minCurrent: 6,
+		maxCurrent: 16,
+		ChargeProfile: []ProfileEntry{
+			{Start: "22:00", End: "06:00", MaxCurrent: 10},
+			{Start: "12:00", End: "14:00", MinCurrent: 8},
+			{Start: "18:00", End: "19:00", MaxCurrent: 4},
+		},
+	}
+
+	for _, tc := range []struct {
+		hour     int
+		min, max float64
+	}{
+		{0, 6, 10},
+		{5, 6, 10},
+		{6, 6, 16}, // no match
+		{12, 8, 16},
+		{18, 4, 4},
+		{22, 6, 10},
+	} {
+		t.Logf("%+v", tc)
+
+		clck.Set(time.Date(now.Year(), now.Month(), now.Day(), tc.hour, 30, 0, 0, time.Local))
+		minCurrent, maxCurrent := lp.profileCurrents()
+		assert.Equal(t, tc.min, minCurrent)
+		assert.Equal(t, tc.max, maxCurrent)
+		assert.Equal(t, tc.min, lp.effectiveMinCurrent())
+		assert.Equal(t, tc.max, lp.effectiveMaxCurrent())
+	}
+}
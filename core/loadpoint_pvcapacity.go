@@ -0,0 +1,80 @@
+package core
+
+import (
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// pvCapacityDays is the number of days of surplus peaks used for estimating pv capacity
+const pvCapacityDays = 30
+
+// pvPeak is the maximum surplus power of a single day
+type pvPeak struct {
+	Day   time.Time
+	Power float64
+}
+
+// addPvPeak records the surplus power for the day of ts and drops days outside the estimation window
+func addPvPeak(peaks []pvPeak, ts time.Time, power float64) []pvPeak {
+	day := time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, ts.Location())
+
+	if n := len(peaks); n > 0 && peaks[n-1].Day.Equal(day) {
+		peaks[n-1].Power = max(peaks[n-1].Power, power)
+	} else {
+		peaks = append(peaks, pvPeak{Day: day, Power: power})
+	}
+
+	cutoff := day.AddDate(0, 0, -pvCapacityDays+1)
+
+	var i int
+	for i < len(peaks) && peaks[i].Day.Before(cutoff) {
+		i++
+	}
+
+	return peaks[i:]
+}
+
+// maxPvPeak returns the largest daily surplus peak
+func maxPvPeak(peaks []pvPeak) float64 {
+	var res float64
+	for _, p := range peaks {
+		res = max(res, p.Power)
+	}
+	return res
+}
+
+// updatePvCapacityEstimate tracks the rolling surplus peak available to the loadpoint as pv capacity estimate
+func (lp *Loadpoint) updatePvCapacityEstimate(sitePower float64) {
+	if !lp.PVCapacityEstimate {
+		return
+	}
+
+	// own charge power is part of the surplus
+	surplus := lp.chargePower - sitePower
+
+	peaks := addPvPeak(slices.Clone(lp.pvPeaks), lp.clock.Now(), max(0, surplus))
+	changed := !slices.Equal(peaks, lp.pvPeaks)
+
+	lp.pvPeaks = peaks
+	lp.pvCapacityEstimate = maxPvPeak(lp.pvPeaks)
+
+	lp.publish(keys.PvCapacityEstimateW, lp.pvCapacityEstimate)
+
+	// persist estimate across restarts
+	if changed {
+		if err := lp.settings.Save(keys.PvPeaks, lp.pvPeaks); err != nil {
+			lp.log.ERROR.Printf("pv capacity estimate: %v", err)
+		}
+	}
+}
+
+// pvThreshold returns the pv mode threshold in W. The percent threshold applies once pv capacity has been estimated.
+func (lp *Loadpoint) pvThreshold(c ThresholdConfig) float64 {
+	if !lp.PVCapacityEstimate || c.ThresholdPercent == 0 || lp.pvCapacityEstimate == 0 {
+		return c.Threshold
+	}
+
+	return c.ThresholdPercent / 100 * lp.pvCapacityEstimate
+}
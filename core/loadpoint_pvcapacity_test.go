@@ -0,0 +1,83 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPvCapacityEstimate(t *testing.T) {
+	clck := clock.NewMock()
+	clck.Set(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+
+	lp := &Loadpoint{
+		log:                util.NewLogger("foo"),
+		clock:              clck,
+		PVCapacityEstimate: true,
+	}
+
+	// 30 days with hourly samples, daily peak at noon rising to 9kW on day 10
+	for day := 0; day < 30; day++ {
+		peak := 6000.0
+		if day == 10 {
+			peak = 9000
+		}
+
+		for hour := 0; hour < 24; hour++ {
+			clck.Set(time.Date(2024, 5, 1+day, hour, 0, 0, 0, time.UTC))
+
+			sitePower := 500.0 // night consumption
+			if hour >= 8 && hour <= 16 {
+				sitePower = -peak * (1 - float64((hour-12)*(hour-12))/20)
+			}
+
+			// part of the surplus is used for charging
+			lp.chargePower = 0
+			if hour == 12 {
+				lp.chargePower = 2000
+				sitePower += 2000
+			}
+
+			lp.updatePvCapacityEstimate(sitePower)
+		}
+	}
+
+	assert.Len(t, lp.pvPeaks, 30)
+	assert.Equal(t, 9000.0, lp.pvCapacityEstimate)
+
+	// thresholds in percent of capacity
+	assert.Equal(t, -900.0, lp.pvThreshold(ThresholdConfig{Threshold: -100, ThresholdPercent: -10}))
+	assert.Equal(t, 450.0, lp.pvThreshold(ThresholdConfig{ThresholdPercent: 5}))
+
+	// without percent threshold the threshold is in W
+	assert.Equal(t, -100.0, lp.pvThreshold(ThresholdConfig{Threshold: -100}))
+
+	// peak leaves the 30 day window
+	clck.Set(time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC))
+	lp.updatePvCapacityEstimate(-5000)
+	assert.Equal(t, 6000.0, lp.pvCapacityEstimate)
+
+	// thresholds in W without estimation
+	lp.PVCapacityEstimate = false
+	assert.Equal(t, -100.0, lp.pvThreshold(ThresholdConfig{Threshold: -100, ThresholdPercent: -10}))
+}
+
+func TestAddPvPeak(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	var peaks []pvPeak
+	peaks = addPvPeak(peaks, day.Add(10*time.Hour), 1000)
+	peaks = addPvPeak(peaks, day.Add(12*time.Hour), 3000)
+	peaks = addPvPeak(peaks, day.Add(14*time.Hour), 2000)
+	assert.Equal(t, []pvPeak{{Day: day, Power: 3000}}, peaks)
+
+	peaks = addPvPeak(peaks, day.AddDate(0, 0, pvCapacityDays-1), 500)
+	assert.Len(t, peaks, 2)
+
+	peaks = addPvPeak(peaks, day.AddDate(0, 0, pvCapacityDays), 500)
+	assert.Len(t, peaks, 2)
+	assert.Equal(t, 500.0, maxPvPeak(peaks))
+}
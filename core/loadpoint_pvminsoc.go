@@ -0,0 +1,15 @@
+package core
+
+import "github.com/evcc-io/evcc/core/keys"
+
+// pvMinSocActive returns true if the vehicle soc is known and below the pv min soc
+func (lp *Loadpoint) pvMinSocActive() bool {
+	res := lp.PVMinSoc > 0 && lp.vehicleSoc > 0 && lp.vehicleSoc < float64(lp.PVMinSoc)
+	if res {
+		lp.log.DEBUG.Printf("pv min soc: charging at min current at vehicle soc %.0f%% (< %d%%)", lp.vehicleSoc, lp.PVMinSoc)
+	}
+
+	lp.publish(keys.MinSocActive, res)
+
+	return res
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPvMinSoc(t *testing.T) {
+	Voltage = 230 // V
+
+	lp := &Loadpoint{
+		log:        util.NewLogger("foo"),
+		clock:      clock.NewMock(),
+		charger:    api.NewMockCharger(gomock.NewController(t)),
+		status:     api.StatusB,
+		phases:     1,
+		minCurrent: minA,
+		maxCurrent: maxA,
+		PVMinSoc:   20,
+	}
+
+	// no surplus
+	sitePower := 500.0
+
+	for _, tc := range []struct {
+		soc     float64
+		current float64
+	}{
+		{0, 0}, // unknown soc
+		{10, minA},
+		{20, 0},
+		{50, 0},
+	} {
+		t.Logf("%+v", tc)
+
+		lp.vehicleSoc = tc.soc
+		assert.Equal(t, tc.current, lp.pvMaxCurrent(api.ModePV, sitePower, false, false))
+	}
+
+	// surplus above min current is used
+	lp.vehicleSoc = 10
+	lp.status = api.StatusC
+	lp.enabled = true
+	lp.chargeCurrent = minA
+	assert.Equal(t, 10.0, lp.pvMaxCurrent(api.ModePV, -920, false, false))
+}
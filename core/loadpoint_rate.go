@@ -0,0 +1,34 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// nightRate returns true if the time of day is within the night rate period
+func nightRate(now time.Time, dayStart, nightStart time.Duration) bool {
+	if dayStart == nightStart {
+		return false
+	}
+
+	tod := time.Duration(now.Hour())*time.Hour + time.Duration(now.Minute())*time.Minute + time.Duration(now.Second())*time.Second
+
+	// night rate within the same day
+	if nightStart < dayStart {
+		return tod >= nightStart && tod < dayStart
+	}
+
+	// night rate across midnight
+	return tod >= nightStart || tod < dayStart
+}
+
+// nightRateActive returns true if the night rate is active
+func (lp *Loadpoint) nightRateActive() bool {
+	return nightRate(lp.clock.Now(), lp.DayRateStart, lp.NightRateStart)
+}
+
+// publishNightRate publishes the night rate state
+func (lp *Loadpoint) publishNightRate() {
+	lp.publish(keys.NightRateActive, lp.NightMinCurrent > 0 && lp.nightRateActive())
+}
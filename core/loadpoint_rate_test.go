@@ -0,0 +1,76 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNightRate(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+
+	tc := []struct {
+		day, night time.Duration
+		tod        time.Duration
+		res        bool
+	}{
+		{6 * time.Hour, 22 * time.Hour, 21 * time.Hour, false},
+		{6 * time.Hour, 22 * time.Hour, 22 * time.Hour, true},
+		{6 * time.Hour, 22 * time.Hour, 3 * time.Hour, true},
+		{6 * time.Hour, 22 * time.Hour, 6 * time.Hour, false},
+		{7 * time.Hour, 1 * time.Hour, 30 * time.Minute, false},
+		{7 * time.Hour, 1 * time.Hour, 2 * time.Hour, true},
+		{0, 0, 2 * time.Hour, false}, // not configured
+	}
+
+	for _, tc := range tc {
+		t.Logf("%+v", tc)
+		assert.Equal(t, tc.res, nightRate(day.Add(tc.tod), tc.day, tc.night))
+	}
+}
+
+func TestNightMinCurrent(t *testing.T) {
+	clck := clock.NewMock()
+	clck.Set(time.Date(2024, 3, 1, 21, 0, 0, 0, time.Local))
+
+	lp := &Loadpoint{
+		log:             util.NewLogger("foo"),
+		clock:           clck,
+		mode:            api.ModeMinPV,
+		minCurrent:      6,
+		maxCurrent:      16,
+		DayRateStart:    6 * time.Hour,
+		NightRateStart:  22 * time.Hour,
+		NightMinCurrent: 10,
+	}
+
+	// day rate
+	assert.False(t, lp.nightRateActive())
+	assert.Equal(t, 6.0, lp.effectiveMinCurrent())
+
+	// night rate starts
+	clck.Add(time.Hour)
+	assert.True(t, lp.nightRateActive())
+	assert.Equal(t, 10.0, lp.effectiveMinCurrent())
+
+	uiChan := make(chan util.Param, 1)
+	lp.uiChan = uiChan
+	lp.publishNightRate()
+	assert.Equal(t, util.Param{Key: keys.NightRateActive, Val: true}, <-uiChan)
+	lp.uiChan = nil
+
+	// only in minpv mode
+	lp.mode = api.ModePV
+	assert.Equal(t, 6.0, lp.effectiveMinCurrent())
+	lp.mode = api.ModeMinPV
+
+	// day rate starts
+	clck.Add(8 * time.Hour)
+	assert.False(t, lp.nightRateActive())
+	assert.Equal(t, 6.0, lp.effectiveMinCurrent())
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/api"
+)
+
+// chargerReconnectFailures is the number of consecutive charger errors triggering a reconnect
+const chargerReconnectFailures = 3
+
+// chargerHealthCheck counts consecutive charger errors and reconnects the charger if supported
+func (lp *Loadpoint) chargerHealthCheck(err error) {
+	if err == nil {
+		if lp.chargerUnreachable {
+			lp.log.INFO.Println("charger: reachable again")
+		}
+		lp.chargerFailures = 0
+		lp.chargerUnreachable = false
+		return
+	}
+
+	lp.chargerFailures++
+	if lp.chargerFailures < chargerReconnectFailures {
+		return
+	}
+
+	rc, ok := lp.charger.(api.Reconnectable)
+	if !ok {
+		return
+	}
+
+	lp.log.WARN.Printf("charger: reconnecting after %d failures", lp.chargerFailures)
+	lp.chargerFailures = 0
+
+	if err := rc.Reconnect(); err != nil {
+		lp.log.ERROR.Printf("charger reconnect: %v", err)
+
+		// notify once until charger recovers
+		if !lp.chargerUnreachable {
+			lp.chargerUnreachable = true
+			lp.pushEvent(evChargerOffline)
+		}
+
+		return
+	}
+
+	lp.log.INFO.Println("charger: reconnected")
+
+	// refresh published charger capabilities
+	lp.publishChargerCapabilities()
+}
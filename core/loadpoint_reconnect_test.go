@@ -0,0 +1,57 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type reconnectableCharger struct {
+	*api.MockCharger
+	*api.MockReconnectable
+}
+
+func TestChargerReconnect(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	pushChan := make(chan push.Event, 1)
+
+	charger := &reconnectableCharger{api.NewMockCharger(ctrl), api.NewMockReconnectable(ctrl)}
+
+	lp := &Loadpoint{
+		log:      util.NewLogger("foo"),
+		charger:  charger,
+		pushChan: pushChan,
+	}
+
+	errOffline := errors.New("offline")
+
+	// fails 3 times, then reconnects
+	charger.MockCharger.EXPECT().Status().Return(api.StatusNone, errOffline).Times(3)
+	charger.MockReconnectable.EXPECT().Reconnect().Return(nil)
+	for range 3 {
+		assert.Error(t, lp.updateChargerStatus())
+	}
+	assert.Equal(t, 0, lp.chargerFailures)
+	assert.Empty(t, pushChan)
+
+	// failing reconnect notifies once
+	charger.MockCharger.EXPECT().Status().Return(api.StatusNone, errOffline).Times(6)
+	charger.MockReconnectable.EXPECT().Reconnect().Return(errOffline).Times(2)
+	for range 6 {
+		assert.Error(t, lp.updateChargerStatus())
+	}
+	require.Len(t, pushChan, 1)
+	assert.Equal(t, evChargerOffline, (<-pushChan).Event)
+	assert.True(t, lp.chargerUnreachable)
+
+	// recovery resets state
+	lp.chargerHealthCheck(nil)
+	assert.False(t, lp.chargerUnreachable)
+	assert.Equal(t, 0, lp.chargerFailures)
+}
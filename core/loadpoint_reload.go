@@ -0,0 +1,198 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/fsnotify/fsnotify"
+	"gopkg.in/yaml.v3"
+)
+
+// WatchConfig watches the config file and applies non-breaking loadpoint changes without restart until ctx is cancelled
+func (lp *Loadpoint) WatchConfig(ctx context.Context, configPath string) error {
+	watcher, err := newConfigWatcher(configPath)
+	if err != nil {
+		return err
+	}
+
+	go lp.watchConfig(ctx, watcher, configPath)
+
+	return nil
+}
+
+// newConfigWatcher creates a watcher for the config file's directory
+func newConfigWatcher(configPath string) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	// watch the directory since editors often replace the file instead of writing it
+	if err := watcher.Add(filepath.Dir(configPath)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	return watcher, nil
+}
+
+// watchConfig reloads the loadpoint configuration on file changes until ctx is cancelled
+func (lp *Loadpoint) watchConfig(ctx context.Context, watcher *fsnotify.Watcher, configPath string) {
+	defer watcher.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case ev, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			if filepath.Clean(ev.Name) != filepath.Clean(configPath) || !ev.Has(fsnotify.Write) && !ev.Has(fsnotify.Create) {
+				continue
+			}
+
+			if err := lp.reloadConfig(configPath); err != nil {
+				lp.log.ERROR.Printf("config reload: %v", err)
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			lp.log.ERROR.Printf("config watcher: %v", err)
+		}
+	}
+}
+
+// reloadConfig reads the loadpoint section from the config file and applies non-breaking changes
+func (lp *Loadpoint) reloadConfig(configPath string) error {
+	b, err := os.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	var conf struct {
+		Loadpoints []map[string]any
+	}
+
+	if err := yaml.Unmarshal(b, &conf); err != nil {
+		return err
+	}
+
+	// loadpoints are identified by their charger
+	var other map[string]any
+	for _, lpc := range conf.Loadpoints {
+		if ref, ok := lpc["charger"].(string); ok && ref == lp.ChargerRef {
+			other = lpc
+			break
+		}
+	}
+
+	if other == nil {
+		return errors.New("loadpoint not found, charger changed?")
+	}
+
+	cc := NewLoadpoint(lp.log, nil)
+	if err := util.DecodeOther(other, cc); err != nil {
+		return err
+	}
+
+	lp.applyConfig(cc)
+
+	return nil
+}
+
+// applyConfig applies non-breaking configuration changes
+func (lp *Loadpoint) applyConfig(cc *Loadpoint) {
+	if cc.VehicleRef != lp.VehicleRef || cc.MeterRef != lp.MeterRef {
+		lp.log.WARN.Println("config reload: vehicle and meter changes require restart")
+	}
+
+	lp.SetPriority(cc.Priority_)
+	lp.SetEnableThreshold(cc.Enable.Threshold)
+	lp.SetDisableThreshold(cc.Disable.Threshold)
+
+	if err := lp.SetSocTarget(cc.SocTarget); err != nil {
+		lp.log.ERROR.Printf("config reload: %v", err)
+	}
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.applyCurrents(cc.MinCurrent_, cc.MaxCurrent_)
+
+	if soc := cc.PVMinSoc; soc != lp.PVMinSoc {
+		if soc < 0 || soc > 100 {
+			lp.log.ERROR.Printf("config reload: invalid pv min soc: %d", soc)
+		} else {
+			lp.log.INFO.Println("config reload: pv min soc:", soc)
+			lp.PVMinSoc = soc
+		}
+	}
+
+	lp.Enable.Delay = cc.Enable.Delay
+	lp.Disable.Delay = cc.Disable.Delay
+	lp.Enable.ThresholdPercent = cc.Enable.ThresholdPercent
+	lp.Disable.ThresholdPercent = cc.Disable.ThresholdPercent
+	lp.SocRamp = cc.SocRamp
+
+	if mode := cc.Mode_; mode != "" && mode != lp.Mode_ {
+		lp.log.INFO.Println("config reload: default mode:", mode)
+		lp.Mode_ = mode
+	}
+
+	if interval := cc.Soc.Poll.Interval; interval != lp.Soc.Poll.Interval {
+		lp.log.INFO.Println("config reload: soc poll interval:", interval)
+		lp.Soc.Poll.Interval = interval
+	}
+
+	if interval := cc.Soc.Poll.ChargingInterval; interval != lp.Soc.Poll.ChargingInterval {
+		lp.log.INFO.Println("config reload: soc charging poll interval:", interval)
+		lp.Soc.Poll.ChargingInterval = interval
+	}
+
+	if mode := strings.ToLower(cc.Soc.Poll.Mode); mode != lp.Soc.Poll.Mode && cc.Soc.Poll.Mode != "" {
+		lp.log.WARN.Println("config reload: soc poll mode changes require restart")
+	}
+
+	lp.requestUpdate()
+}
+
+// applyCurrents applies changed min and max current configuration (no mutex)
+func (lp *Loadpoint) applyCurrents(minCurrent, maxCurrent float64) {
+	// only changed values are applied to not override currents set via ui or api
+	if minCurrent == lp.MinCurrent_ && maxCurrent == lp.MaxCurrent_ {
+		return
+	}
+
+	newMin, newMax := lp.minCurrent, lp.maxCurrent
+	if minCurrent > 0 && minCurrent != lp.MinCurrent_ {
+		newMin = minCurrent
+	}
+	if maxCurrent > 0 && maxCurrent != lp.MaxCurrent_ {
+		newMax = maxCurrent
+	}
+
+	if newMin > newMax {
+		lp.log.ERROR.Printf("config reload: min current %.3gA must be smaller or equal than max current %.3gA", newMin, newMax)
+		return
+	}
+
+	lp.MinCurrent_, lp.MaxCurrent_ = minCurrent, maxCurrent
+
+	if newMin != lp.minCurrent {
+		lp.log.INFO.Println("config reload: min current:", newMin)
+		lp.setMinCurrent(newMin)
+	}
+	if newMax != lp.maxCurrent {
+		lp.log.INFO.Println("config reload: max current:", newMax)
+		lp.setMaxCurrent(newMax)
+	}
+}
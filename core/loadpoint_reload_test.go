@@ -0,0 +1,94 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadConfig(t *testing.T) {
+	const config = `
+loadpoints:
+- title: Garage
+  charger: wallbox
+  maxCurrent: %d
+  socTarget: 80
+  enable:
+    threshold: %s
+    delay: 2m
+`
+	file := filepath.Join(t.TempDir(), "evcc.yaml")
+	write := func(maxCurrent int, threshold string) {
+		require.NoError(t, os.WriteFile(file, []byte(fmt.Sprintf(config, maxCurrent, threshold)), 0o644))
+	}
+	write(16, "0")
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.ChargerRef = "wallbox"
+
+	watcher, err := newConfigWatcher(file)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		lp.watchConfig(ctx, watcher, file)
+		close(done)
+	}()
+
+	write(20, "-500")
+	assert.Eventually(t, func() bool {
+		return lp.GetEnableThreshold() == -500
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, 20.0, lp.GetMaxCurrent())
+	assert.Equal(t, 6.0, lp.GetMinCurrent())
+	assert.Equal(t, 80, lp.GetSocTarget())
+
+	lp.RLock()
+	assert.Equal(t, 2*time.Minute, lp.Enable.Delay)
+	lp.RUnlock()
+
+	// watcher stops on cancel
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("config watcher not stopped")
+	}
+
+	// charger changes are ignored
+	require.Error(t, lp.reloadConfig(filepath.Join(t.TempDir(), "missing.yaml")))
+	require.NoError(t, os.WriteFile(file, []byte(`
+loadpoints:
+- charger: other
+  enable:
+    threshold: -1000
+`), 0o644))
+	require.Error(t, lp.reloadConfig(file))
+	assert.Equal(t, -500.0, lp.GetEnableThreshold())
+}
+
+func TestReloadConfigCurrents(t *testing.T) {
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+
+	// min current above max current is rejected
+	lp.applyCurrents(20, 0)
+	assert.Equal(t, 6.0, lp.minCurrent)
+
+	lp.applyCurrents(8, 32)
+	assert.Equal(t, 8.0, lp.minCurrent)
+	assert.Equal(t, 32.0, lp.maxCurrent)
+
+	// unchanged config keeps currents set via ui
+	lp.minCurrent = 10
+	lp.applyCurrents(8, 32)
+	assert.Equal(t, 10.0, lp.minCurrent)
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strings"
+
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// RemoteStart authorizes a single charging session in immediate mode until the vehicle disconnects
+func (lp *Loadpoint) RemoteStart(token string) error {
+	if !lp.validToken(token) {
+		return errors.New("invalid session token")
+	}
+
+	if !lp.connected() {
+		return errors.New("vehicle not connected")
+	}
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.INFO.Printf("remote session started: %s", maskToken(token))
+
+	lp.remoteSession = token
+	lp.publishRemoteSession()
+
+	lp.requestUpdate()
+
+	return nil
+}
+
+// validToken checks if token is contained in the allowed tokens
+func (lp *Loadpoint) validToken(token string) bool {
+	if token == "" {
+		return false
+	}
+
+	for _, t := range lp.AllowedTokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// remoteSessionActive returns true if a remotely started session is active
+func (lp *Loadpoint) remoteSessionActive() bool {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.remoteSession != ""
+}
+
+// clearRemoteSession ends the remote session
+func (lp *Loadpoint) clearRemoteSession() {
+	lp.Lock()
+	defer lp.Unlock()
+
+	if lp.remoteSession != "" {
+		lp.log.INFO.Println("remote session ended")
+	}
+
+	lp.remoteSession = ""
+	lp.publishRemoteSession()
+}
+
+// publishRemoteSession publishes remote session status. Must be called with lock held.
+func (lp *Loadpoint) publishRemoteSession() {
+	lp.publish(keys.RemoteSessionActive, lp.remoteSession != "")
+	lp.publish(keys.RemoteSessionToken, maskToken(lp.remoteSession))
+}
+
+// maskToken hides all but the last 4 characters of the token
+func maskToken(token string) string {
+	if len(token) <= 4 {
+		return strings.Repeat("*", len(token))
+	}
+	return strings.Repeat("*", len(token)-4) + token[len(token)-4:]
+}
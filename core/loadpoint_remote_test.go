@@ -0,0 +1,87 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRemoteStartToken(t *testing.T) {
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		status:        api.StatusB,
+		AllowedTokens: []string{"secret-1", "secret-2"},
+	}
+
+	assert.Error(t, lp.RemoteStart(""))
+	assert.Error(t, lp.RemoteStart("secret"))
+	assert.Error(t, lp.RemoteStart("secret-10"))
+	assert.False(t, lp.remoteSessionActive())
+
+	require.NoError(t, lp.RemoteStart("secret-2"))
+	assert.True(t, lp.remoteSessionActive())
+
+	lp.clearRemoteSession()
+	lp.status = api.StatusA
+	assert.Error(t, lp.RemoteStart("secret-1"), "not connected")
+	assert.False(t, lp.remoteSessionActive())
+}
+
+func TestMaskToken(t *testing.T) {
+	assert.Equal(t, "", maskToken(""))
+	assert.Equal(t, "****", maskToken("abcd"))
+	assert.Equal(t, "****efgh", maskToken("abcdefgh"))
+}
+
+func TestRemoteStartSession(t *testing.T) {
+	clck := clock.NewMock()
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		bus:           evbus.New(),
+		clock:         clck,
+		charger:       charger,
+		chargeMeter:   &Null{}, // silence nil panics
+		chargeRater:   &Null{}, // silence nil panics
+		chargeTimer:   &Null{}, // silence nil panics
+		wakeUpTimer:   NewTimer(),
+		sessionEnergy: NewEnergyMetrics(),
+		minCurrent:    minA,
+		maxCurrent:    maxA,
+		status:        api.StatusB,
+		mode:          api.ModeOff,
+		Mode_:         api.ModeOff, // default mode
+		AllowedTokens: []string{"secret"},
+	}
+
+	attachListeners(t, lp)
+
+	t.Log("remote start charges despite mode off")
+	require.NoError(t, lp.RemoteStart("secret"))
+	charger.EXPECT().Enabled().Return(lp.enabled, nil)
+	charger.EXPECT().Status().Return(api.StatusB, nil)
+	charger.EXPECT().MaxCurrent(int64(maxA)).Return(nil)
+	lp.Update(500, false, false, false, 0, nil, nil)
+
+	assert.Equal(t, api.ModeOff, lp.GetMode(), "configured mode unchanged")
+	assert.Equal(t, float64(maxA), lp.chargeCurrent)
+
+	t.Log("session ends when disconnected")
+	clck.Add(5 * time.Minute)
+	charger.EXPECT().Enabled().Return(lp.enabled, nil)
+	charger.EXPECT().Status().Return(api.StatusA, nil)
+	charger.EXPECT().Enable(false).Return(nil)
+	lp.Update(500, false, false, false, 0, nil, nil)
+
+	assert.False(t, lp.remoteSessionActive())
+	assert.False(t, lp.enabled)
+}
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// commandRetryDelay is the default delay before retrying a failed vehicle command
+const commandRetryDelay = 30 * time.Second
+
+// readBackOff returns the configured retry backoff for charger status and charge meter reads
+// or the given default if no retries are configured
+func (lp *Loadpoint) readBackOff(def func() backoff.BackOff) backoff.BackOff {
+	if lp.ReadRetry.Count <= 0 {
+		return def()
+	}
+
+	bo := backoff.NewExponentialBackOff()
+	bo.MaxElapsedTime = 0 // limited by count
+	bo.RandomizationFactor = 0
+	bo.Multiplier = 2
+	if lp.ReadRetry.Delay > 0 {
+		bo.InitialInterval = lp.ReadRetry.Delay
+	}
+
+	return backoff.WithMaxRetries(bo, uint64(lp.ReadRetry.Count))
+}
+
+// retryState is the pending retry of a failed vehicle command
+type retryState struct {
+	fn       func() error
+	attempts int
+	next     time.Time
+}
+
+// retryDelay returns the backoff delay after given number of failed attempts
+func (lp *Loadpoint) retryDelay(attempts int) time.Duration {
+	delay := lp.CommandRetry.InitialDelay
+	if delay <= 0 {
+		delay = commandRetryDelay
+	}
+
+	for i := 1; i < attempts && (lp.CommandRetry.MaxDelay <= 0 || delay < lp.CommandRetry.MaxDelay); i++ {
+		delay *= 2
+	}
+
+	if lp.CommandRetry.MaxDelay > 0 {
+		delay = min(delay, lp.CommandRetry.MaxDelay)
+	}
+
+	return delay
+}
+
+// vehicleCommand executes a vehicle write command and schedules retries if it fails
+func (lp *Loadpoint) vehicleCommand(name string, fn func() error) error {
+	delete(lp.commandRetries, name)
+
+	err := fn()
+	if err != nil && lp.CommandRetry.Attempts > 1 {
+		if lp.commandRetries == nil {
+			lp.commandRetries = make(map[string]retryState)
+		}
+
+		lp.commandRetries[name] = retryState{
+			fn:       fn,
+			attempts: 1,
+			next:     lp.clock.Now().Add(lp.retryDelay(1)),
+		}
+	}
+
+	return err
+}
+
+// retryVehicleCommands executes all due vehicle command retries
+func (lp *Loadpoint) retryVehicleCommands() {
+	for name, state := range lp.commandRetries {
+		if lp.clock.Now().Before(state.next) {
+			continue
+		}
+
+		state.attempts++
+
+		err := state.fn()
+		switch {
+		case err == nil:
+			lp.log.DEBUG.Printf("%s: succeeded after %d attempts", name, state.attempts)
+			delete(lp.commandRetries, name)
+
+		case state.attempts >= lp.CommandRetry.Attempts:
+			lp.log.ERROR.Printf("%s: giving up after %d attempts: %v", name, state.attempts, err)
+			delete(lp.commandRetries, name)
+
+		default:
+			delay := lp.retryDelay(state.attempts)
+			lp.log.DEBUG.Printf("%s: attempt %d failed, retrying in %v: %v", name, state.attempts, delay, err)
+			state.next = lp.clock.Now().Add(delay)
+			lp.commandRetries[name] = state
+		}
+	}
+}
+
+// clearVehicleCommands drops all pending vehicle command retries
+func (lp *Loadpoint) clearVehicleCommands() {
+	clear(lp.commandRetries)
+}
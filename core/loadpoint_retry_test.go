@@ -0,0 +1,123 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVehicleCommandRetry(t *testing.T) {
+	clck := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:   util.NewLogger("foo"),
+		clock: clck,
+		CommandRetry: CommandRetryConfig{
+			Attempts:     5,
+			InitialDelay: 10 * time.Second,
+			MaxDelay:     time.Minute,
+		},
+	}
+
+	var attempts int
+	fn := func() error {
+		if attempts++; attempts <= 2 {
+			return errors.New("transient")
+		}
+		return nil
+	}
+
+	assert.Error(t, lp.vehicleCommand("test", fn))
+	assert.Equal(t, 1, attempts)
+
+	// not yet due
+	lp.retryVehicleCommands()
+	assert.Equal(t, 1, attempts)
+
+	clck.Add(10 * time.Second)
+	lp.retryVehicleCommands()
+	assert.Equal(t, 2, attempts)
+
+	// backoff doubled
+	clck.Add(10 * time.Second)
+	lp.retryVehicleCommands()
+	assert.Equal(t, 2, attempts)
+
+	clck.Add(10 * time.Second)
+	lp.retryVehicleCommands()
+	assert.Equal(t, 3, attempts)
+	assert.Empty(t, lp.commandRetries)
+
+	// no further attempts after success
+	clck.Add(time.Hour)
+	lp.retryVehicleCommands()
+	assert.Equal(t, 3, attempts)
+}
+
+func TestVehicleCommandRetryExhausted(t *testing.T) {
+	clck := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:          util.NewLogger("foo"),
+		clock:        clck,
+		CommandRetry: CommandRetryConfig{Attempts: 2},
+	}
+
+	var attempts int
+	fn := func() error {
+		attempts++
+		return errors.New("permanent")
+	}
+
+	assert.Error(t, lp.vehicleCommand("test", fn))
+
+	for range 3 {
+		clck.Add(time.Hour)
+		lp.retryVehicleCommands()
+	}
+
+	assert.Equal(t, 2, attempts)
+	assert.Empty(t, lp.commandRetries)
+}
+
+func TestRetryDelay(t *testing.T) {
+	lp := &Loadpoint{
+		CommandRetry: CommandRetryConfig{InitialDelay: 10 * time.Second, MaxDelay: 30 * time.Second},
+	}
+
+	assert.Equal(t, 10*time.Second, lp.retryDelay(1))
+	assert.Equal(t, 20*time.Second, lp.retryDelay(2))
+	assert.Equal(t, 30*time.Second, lp.retryDelay(3))
+	assert.Equal(t, 30*time.Second, lp.retryDelay(10))
+}
+
+func TestReadRetry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+
+	lp := newChargerTestLoadpoint(clock.NewMock(), charger)
+	lp.status = api.StatusA
+
+	// no retry by default
+	charger.EXPECT().Status().Return(api.StatusNone, errors.New("timeout"))
+	assert.Error(t, lp.updateChargerStatus())
+
+	// configured retries
+	lp.ReadRetry = ReadRetryConfig{Count: 2, Delay: time.Millisecond}
+
+	gomock.InOrder(
+		charger.EXPECT().Status().Return(api.StatusNone, errors.New("timeout")).Times(2),
+		charger.EXPECT().Status().Return(api.StatusA, nil),
+	)
+	require.NoError(t, lp.updateChargerStatus())
+
+	charger.EXPECT().Status().Return(api.StatusNone, errors.New("timeout")).Times(3)
+	assert.Error(t, lp.updateChargerStatus())
+}
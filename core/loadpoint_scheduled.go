@@ -0,0 +1,86 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// GetDepartureTime returns the departure time for scheduled charging
+func (lp *Loadpoint) GetDepartureTime() time.Time {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.departureTime
+}
+
+// setDepartureTime sets the departure time (no mutex)
+func (lp *Loadpoint) setDepartureTime(departure time.Time) {
+	lp.departureTime = departure
+	lp.publish(keys.DepartureTime, departure)
+	lp.settings.SetTime(keys.DepartureTime, departure)
+}
+
+// SetDepartureTime sets the departure time for scheduled charging. Zero time removes the departure time.
+func (lp *Loadpoint) SetDepartureTime(departure time.Time) error {
+	lp.Lock()
+	defer lp.Unlock()
+
+	if !departure.IsZero() && departure.Before(lp.clock.Now()) {
+		return errors.New("timestamp is in the past")
+	}
+
+	lp.log.DEBUG.Printf("set departure time: %v", departure.Round(time.Second).Local())
+
+	if !lp.departureTime.Equal(departure) {
+		lp.setDepartureTime(departure)
+		lp.requestUpdate()
+	}
+
+	return nil
+}
+
+// scheduledCurrent returns the constant current required to reach the limit soc by departure time.
+// Returns false if the current cannot be determined.
+func (lp *Loadpoint) scheduledCurrent(minCurrent, maxCurrent float64) (float64, bool) {
+	departure := lp.GetDepartureTime()
+	remaining := lp.clock.Until(departure)
+	if departure.IsZero() || remaining <= 0 {
+		return 0, false
+	}
+
+	capacity := lp.vehicleCapacity()
+	if capacity <= 0 {
+		return 0, false
+	}
+
+	// remaining energy in kWh
+	energy := capacity * (float64(lp.effectiveLimitSoc()) - lp.vehicleSoc) / 100
+	if energy <= 0 {
+		return 0, true
+	}
+
+	power := energy * 1e3 / remaining.Hours()
+	current := lp.powerToCurrent(power, lp.ActivePhases())
+
+	return min(max(current, minCurrent), maxCurrent), true
+}
+
+// scheduledCharging charges at the constant current required to reach the limit soc by departure time.
+// Pv surplus exceeding the scheduled current is used as well, reducing the scheduled current in subsequent cycles.
+func (lp *Loadpoint) scheduledCharging(sitePower float64, batteryBuffered, batteryStart bool) error {
+	current, ok := lp.scheduledCurrent(lp.effectiveMinCurrent(), lp.effectiveMaxCurrent())
+	lp.publish(keys.ScheduledCurrent, current)
+
+	if !ok {
+		lp.log.DEBUG.Println("scheduled charging: no departure time, charging now")
+		return lp.fastCharging()
+	}
+
+	if pvCurrent := lp.pvMaxCurrent(api.ModePV, sitePower, batteryBuffered, batteryStart); pvCurrent > current {
+		current = pvCurrent
+	}
+
+	return lp.setLimit(current)
+}
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestScheduledCurrent(t *testing.T) {
+	Voltage = 230 // V
+
+	ctrl := gomock.NewController(t)
+	clck := clock.NewMock()
+
+	vehicle := api.NewMockVehicle(ctrl)
+	vehicle.EXPECT().Capacity().Return(46.0).AnyTimes()
+	vehicle.EXPECT().Phases().Return(0).AnyTimes()
+
+	lp := &Loadpoint{
+		log:        util.NewLogger("foo"),
+		clock:      clck,
+		vehicle:    vehicle,
+		phases:     3,
+		vehicleSoc: 50,
+	}
+
+	// no departure time
+	_, ok := lp.scheduledCurrent(minA, maxA)
+	assert.False(t, ok)
+
+	assert.Error(t, lp.SetDepartureTime(clck.Now().Add(-time.Minute)))
+
+	for _, tc := range []struct {
+		remaining time.Duration
+		soc       float64
+		current   float64
+	}{
+		{10 * time.Hour, 50, minA},    // 23kWh in 10h = 2.3kW
+		{4 * time.Hour, 50, 25.0 / 3}, // 23kWh in 4h = 5.75kW
+		{time.Hour, 50, maxA},         // 23kWh in 1h = 23kW
+		{time.Hour, 100, 0},           // limit reached
+	} {
+		t.Log(tc)
+
+		require.NoError(t, lp.SetDepartureTime(clck.Now().Add(tc.remaining)))
+		lp.vehicleSoc = tc.soc
+
+		current, ok := lp.scheduledCurrent(minA, maxA)
+		assert.True(t, ok)
+		assert.InDelta(t, tc.current, current, 1e-6)
+	}
+
+	// surplus charging reduces remaining energy and thereby scheduled current
+	require.NoError(t, lp.SetDepartureTime(clck.Now().Add(2*time.Hour)))
+	lp.vehicleSoc = 75
+	current, _ := lp.scheduledCurrent(minA, maxA)
+	assert.InDelta(t, 25.0/3, current, 1e-6)
+
+	// departure passed- charge now
+	clck.Add(2 * time.Hour)
+	_, ok = lp.scheduledCurrent(minA, maxA)
+	assert.False(t, ok)
+}
@@ -31,6 +31,7 @@ func (lp *Loadpoint) createSession() {
 	}
 
 	lp.session = lp.db.New(lp.chargeMeterTotal())
+	lp.session.Connected = lp.connectedTime
 
 	if vehicle := lp.GetVehicle(); vehicle != nil {
 		lp.session.Vehicle = vehicle.Title()
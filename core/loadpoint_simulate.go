@@ -0,0 +1,77 @@
+package core
+
+import (
+	"reflect"
+	"sync"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/evcc-io/evcc/api"
+)
+
+// simCharger hides optional charger capabilities like phase switching from the simulation
+type simCharger struct {
+	api.Charger
+}
+
+// simulation returns a copy of the loadpoint that neither publishes nor controls the charger
+func (lp *Loadpoint) simulation() *Loadpoint {
+	lp.RLock()
+	defer lp.RUnlock()
+
+	// copy via reflection to avoid copying the locks
+	sim := new(Loadpoint)
+	reflect.ValueOf(sim).Elem().Set(reflect.ValueOf(lp).Elem())
+
+	sim.RWMutex = sync.RWMutex{}
+	sim.vmu = sync.RWMutex{}
+
+	sim.uiChan = nil
+	sim.pushChan = nil
+	sim.lpChan = nil
+	sim.watchdogC = nil
+	sim.metrics = nil
+	sim.bus = evbus.New()
+	sim.charger = &simCharger{lp.charger}
+
+	return sim
+}
+
+// Simulate returns the charge current the loadpoint would target for the given mode and site power
+// and the reason for the decision. The loadpoint state is not modified, nothing is published and the
+// charger is not controlled. Phase switching is not simulated.
+func (lp *Loadpoint) Simulate(mode api.ChargeMode, sitePower float64) (int64, string) {
+	sim := lp.simulation()
+
+	switch mode {
+	case api.ModeOff:
+		return 0, "off"
+	case api.ModeNow:
+		return int64(sim.effectiveMaxCurrent()), "now"
+	case api.ModeMinPV, api.ModePV:
+	default:
+		return 0, "mode not simulated: " + string(mode)
+	}
+
+	current := sim.pvMaxCurrent(mode, sitePower, false, false)
+	minCurrent := sim.effectiveMinCurrent()
+
+	var reason string
+	switch {
+	case current == 0 && !sim.enabled && !sim.pvTimer.IsZero():
+		reason = "pv enable timer running"
+	case current == 0 && !sim.enabled:
+		reason = "not enough pv surplus"
+	case current == 0:
+		reason = "pv disable timer elapsed"
+	case current == minCurrent && mode == api.ModePV && !sim.enabled:
+		reason = "pv enable timer elapsed"
+	case current == minCurrent && mode == api.ModePV && !sim.pvTimer.IsZero():
+		reason = "pv disable timer running"
+	case current == minCurrent && (mode == api.ModeMinPV || sim.pvMinSocActive()):
+		reason = "min current"
+	default:
+		reason = "pv surplus"
+	}
+
+	return int64(current), reason
+}
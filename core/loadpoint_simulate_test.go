@@ -0,0 +1,69 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSimulate(t *testing.T) {
+	Voltage = 230 // V
+
+	ctrl := gomock.NewController(t)
+	clck := clock.NewMock()
+
+	// no charger calls expected
+	charger := api.NewMockCharger(ctrl)
+
+	for _, tc := range []struct {
+		mode      api.ChargeMode
+		enabled   bool
+		pvTimer   bool
+		sitePower float64
+		current   int64
+		reason    string
+	}{
+		{api.ModeOff, true, false, -5000, 0, "off"},
+		{api.ModeNow, false, false, 5000, 16, "now"},
+		{api.ModeMinPV, true, false, 1000, 6, "min current"},
+		{api.ModePV, true, false, -920, 14, "pv surplus"},
+		{api.ModePV, true, false, -10000, 16, "pv surplus"},
+		{api.ModePV, false, false, 1000, 0, "not enough pv surplus"},
+		{api.ModePV, false, false, -2300, 0, "pv enable timer running"},
+		{api.ModePV, false, true, -2300, 6, "pv enable timer elapsed"},
+		{api.ModePV, true, false, 1000, 6, "pv disable timer running"},
+		{api.ModePV, true, true, 1000, 0, "pv disable timer elapsed"},
+	} {
+		uiChan := make(chan util.Param, 10)
+
+		lp := newChargerTestLoadpoint(clck, charger)
+		lp.uiChan = uiChan
+		lp.phases = 1
+		lp.configuredPhases = 1
+		lp.enabled = tc.enabled
+		lp.chargeCurrent = 10
+		lp.status = api.StatusC
+		lp.Enable.Delay = time.Minute
+		lp.Disable.Delay = time.Minute
+
+		if tc.pvTimer {
+			lp.pvTimer = clck.Now().Add(-time.Hour)
+		}
+		pvTimer := lp.pvTimer
+
+		current, reason := lp.Simulate(tc.mode, tc.sitePower)
+		assert.Equal(t, tc.current, current, tc)
+		assert.Equal(t, tc.reason, reason, tc)
+
+		// no side effects
+		assert.Empty(t, uiChan, tc)
+		assert.Equal(t, pvTimer, lp.pvTimer, tc)
+		assert.Equal(t, tc.enabled, lp.enabled, tc)
+		assert.Equal(t, 10.0, lp.chargeCurrent, tc)
+	}
+}
@@ -0,0 +1,28 @@
+package core
+
+// smoothedSitePower adds the site power sample to the smoothing window and returns the window average
+func (lp *Loadpoint) smoothedSitePower(sitePower float64) float64 {
+	if lp.SmoothingWindow <= 1 {
+		return sitePower
+	}
+
+	lp.sitePowers = append(lp.sitePowers, sitePower)
+	if len(lp.sitePowers) > lp.SmoothingWindow {
+		lp.sitePowers = lp.sitePowers[len(lp.sitePowers)-lp.SmoothingWindow:]
+	}
+
+	var sum float64
+	for _, p := range lp.sitePowers {
+		sum += p
+	}
+	res := sum / float64(len(lp.sitePowers))
+
+	lp.log.DEBUG.Printf("smoothed site power: %.0fW (%d samples)", res, len(lp.sitePowers))
+
+	return res
+}
+
+// resetSitePowerSmoothing discards all site power samples
+func (lp *Loadpoint) resetSitePowerSmoothing() {
+	lp.sitePowers = nil
+}
@@ -0,0 +1,36 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSmoothedSitePower(t *testing.T) {
+	lp := &Loadpoint{
+		log: util.NewLogger("foo"),
+	}
+
+	// disabled
+	assert.Equal(t, 1000.0, lp.smoothedSitePower(1000))
+	assert.Nil(t, lp.sitePowers)
+
+	lp.SmoothingWindow = 3
+
+	for _, tc := range []struct {
+		power, smoothed float64
+	}{
+		{-3000, -3000},
+		{0, -1500},
+		{-3000, -2000},
+		{3000, 0}, // first sample dropped
+		{-6000, -2000},
+	} {
+		t.Logf("%+v", tc)
+		assert.Equal(t, tc.smoothed, lp.smoothedSitePower(tc.power))
+	}
+
+	lp.resetSitePowerSmoothing()
+	assert.Equal(t, 500.0, lp.smoothedSitePower(500))
+}
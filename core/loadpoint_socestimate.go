@@ -0,0 +1,39 @@
+package core
+
+import (
+	"math"
+
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// socEstimateDeviation is the deviation in % between estimated and polled soc that is logged
+const socEstimateDeviation = 5
+
+// estimateSoc extrapolates the vehicle soc from the charged energy between charging polls
+func (lp *Loadpoint) estimateSoc() {
+	if lp.socEstimator == nil {
+		return
+	}
+
+	f := lp.socEstimator.Estimate(lp.getChargedEnergy())
+	if f == lp.vehicleSoc {
+		return
+	}
+
+	lp.socEstimated = true
+	lp.vehicleSoc = f
+
+	lp.log.DEBUG.Printf("vehicle soc estimated: %.1f%%", lp.vehicleSoc)
+	lp.publish(keys.VehicleSoc, lp.vehicleSoc)
+}
+
+// checkSocEstimate compares the polled soc against the estimate
+func (lp *Loadpoint) checkSocEstimate(polled float64) {
+	if lp.socEstimated {
+		if d := polled - lp.vehicleSoc; math.Abs(d) > socEstimateDeviation {
+			lp.log.DEBUG.Printf("vehicle soc estimate deviates by %.1f%%: reset to %.1f%%", d, polled)
+		}
+	}
+
+	lp.socEstimated = false
+}
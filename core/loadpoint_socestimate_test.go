@@ -0,0 +1,75 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/soc"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestChargingSocPollInterval(t *testing.T) {
+	clck := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:    util.NewLogger("foo"),
+		clock:  clck,
+		status: api.StatusC,
+	}
+
+	// poll on every update by default
+	lp.socUpdated = clck.Now()
+	assert.True(t, lp.vehicleSocPollAllowed())
+
+	lp.Soc.Poll.ChargingInterval = 15 * time.Minute
+	assert.False(t, lp.vehicleSocPollAllowed())
+
+	clck.Add(15 * time.Minute)
+	assert.True(t, lp.vehicleSocPollAllowed())
+
+	// soc unknown
+	lp.socUpdated = time.Time{}
+	assert.True(t, lp.vehicleSocPollAllowed())
+}
+
+func TestEstimateSoc(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	// 45kWh user capacity is converted to 50kWh virtual capacity
+	vehicle := api.NewMockVehicle(ctrl)
+	vehicle.EXPECT().Capacity().Return(45.0).AnyTimes()
+	vehicle.EXPECT().Soc().Return(40.0, nil)
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		clock:         clock.NewMock(),
+		vehicle:       vehicle,
+		sessionEnergy: NewEnergyMetrics(),
+		socEstimator:  soc.NewEstimator(util.NewLogger("foo"), api.NewMockCharger(ctrl), vehicle, true),
+	}
+
+	// polled soc starts estimation
+	f, err := lp.socEstimator.Soc(lp.getChargedEnergy())
+	assert.NoError(t, err)
+	lp.checkSocEstimate(f)
+	lp.vehicleSoc = f
+
+	// 10kWh into 50kWh
+	lp.sessionEnergy.Update(10)
+	lp.estimateSoc()
+	assert.InDelta(t, 60, lp.vehicleSoc, 1e-9)
+	assert.True(t, lp.socEstimated)
+
+	// capped at 100%
+	lp.sessionEnergy.Update(100)
+	lp.estimateSoc()
+	assert.Equal(t, 100.0, lp.vehicleSoc)
+
+	// next poll restarts from polled value
+	lp.checkSocEstimate(70)
+	assert.False(t, lp.socEstimated)
+}
@@ -0,0 +1,40 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// LoadpointStatus is a snapshot of the loadpoint's cached state
+type LoadpointStatus struct {
+	Connected      bool           `json:"connected"`
+	Charging       bool           `json:"charging"`
+	ChargePower    float64        `json:"chargePower"`
+	ChargedEnergy  float64        `json:"chargedEnergy"`
+	ChargeDuration time.Duration  `json:"chargeDuration"`
+	VehicleSoc     float64        `json:"vehicleSoc"`
+	ActivePhases   int            `json:"activePhases"`
+	Mode           api.ChargeMode `json:"mode"`
+	ChargeCurrent  float64        `json:"chargeCurrent"`
+}
+
+// Status returns a snapshot of the loadpoint state. It does not access any devices.
+func (lp *Loadpoint) Status() LoadpointStatus {
+	activePhases := lp.ActivePhases()
+
+	lp.RLock()
+	defer lp.RUnlock()
+
+	return LoadpointStatus{
+		Connected:      lp.status == api.StatusB || lp.status == api.StatusC,
+		Charging:       lp.status == api.StatusC,
+		ChargePower:    lp.chargePower,
+		ChargedEnergy:  lp.sessionEnergy.TotalWh(),
+		ChargeDuration: lp.chargeDuration,
+		VehicleSoc:     lp.vehicleSoc,
+		ActivePhases:   activePhases,
+		Mode:           lp.mode,
+		ChargeCurrent:  lp.chargeCurrent,
+	}
+}
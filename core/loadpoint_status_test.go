@@ -1,10 +1,14 @@
 package core
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestStatusEvents(t *testing.T) {
@@ -31,3 +35,36 @@ func TestStatusEvents(t *testing.T) {
 		assert.Equalf(t, tc.events, ev, "from %s to %s got: %v", tc.from, tc.to, ev)
 	}
 }
+
+func TestStatus(t *testing.T) {
+	lp := &Loadpoint{
+		log:            util.NewLogger("foo"),
+		sessionEnergy:  NewEnergyMetrics(),
+		status:         api.StatusC,
+		mode:           api.ModePV,
+		phases:         3,
+		measuredPhases: 1,
+		chargePower:    2300,
+		chargeCurrent:  10,
+		chargeDuration: time.Hour,
+		vehicleSoc:     55,
+	}
+	lp.sessionEnergy.Update(5)
+
+	res := lp.Status()
+	assert.Equal(t, LoadpointStatus{
+		Connected:      true,
+		Charging:       true,
+		ChargePower:    2300,
+		ChargedEnergy:  5000,
+		ChargeDuration: time.Hour,
+		VehicleSoc:     55,
+		ActivePhases:   1,
+		Mode:           api.ModePV,
+		ChargeCurrent:  10,
+	}, res)
+
+	b, err := json.Marshal(res)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"mode":"pv"`)
+}
@@ -0,0 +1,39 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/push"
+)
+
+// sessionSummary returns charged energy in kWh, charge duration, average charge power in kW
+// and cost of the session. Cost is -1 if no tariff is available.
+func (lp *Loadpoint) sessionSummary() map[string]interface{} {
+	energy := lp.getChargedEnergy() / 1e3
+
+	var power float64
+	if duration := lp.chargeDuration; duration > 0 {
+		power = energy / duration.Hours()
+	}
+
+	cost := -1.0
+	if price := lp.sessionEnergy.Price(); price != nil {
+		cost = *price
+	}
+
+	return map[string]interface{}{
+		"summaryEnergy":   energy,
+		"summaryDuration": lp.chargeDuration.Round(time.Second),
+		"summaryPower":    power,
+		"summaryCost":     cost,
+	}
+}
+
+// pushSessionSummary sends the session summary event if energy has been charged
+func (lp *Loadpoint) pushSessionSummary() {
+	if lp.getChargedEnergy() <= 0 {
+		return
+	}
+
+	lp.pushChan <- push.Event{Event: evSessionSummary, Data: lp.sessionSummary()}
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushSessionSummary(t *testing.T) {
+	pushChan := make(chan push.Event, 1)
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		pushChan:      pushChan,
+		sessionEnergy: NewEnergyMetrics(),
+	}
+
+	// nothing charged
+	lp.pushSessionSummary()
+	assert.Empty(t, pushChan)
+
+	lp.sessionEnergy.Update(11)
+	lp.chargeDuration = 2 * time.Hour
+
+	lp.pushSessionSummary()
+	require.Len(t, pushChan, 1)
+
+	ev := <-pushChan
+	assert.Equal(t, evSessionSummary, ev.Event)
+	assert.Equal(t, 11.0, ev.Data["summaryEnergy"])
+	assert.Equal(t, 2*time.Hour, ev.Data["summaryDuration"])
+	assert.Equal(t, 5.5, ev.Data["summaryPower"])
+	assert.Equal(t, -1.0, ev.Data["summaryCost"])
+
+	// with tariff
+	price := 0.3
+	lp.sessionEnergy.Reset()
+	lp.sessionEnergy.SetEnvironment(0, &price, nil)
+	lp.sessionEnergy.Update(10)
+
+	lp.pushSessionSummary()
+	require.Len(t, pushChan, 1)
+
+	ev = <-pushChan
+	assert.InDelta(t, 3.0, ev.Data["summaryCost"], 1e-9)
+}
@@ -1,7 +1,9 @@
 package core
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
@@ -47,3 +49,129 @@ func TestSyncCharger(t *testing.T) {
 		assert.Equal(t, tc.corrected, lp.enabled)
 	}
 }
+
+// currentGetterStub adds current readback to a charger mock
+type currentGetterStub struct {
+	*api.MockCharger
+	current float64
+}
+
+func (c *currentGetterStub) GetMaxCurrent() (float64, error) {
+	return c.current, nil
+}
+
+func TestSyncChargerGuard(t *testing.T) {
+	// charger switch durations must be based on wall clock time
+	recent := time.Now()
+	expired := time.Now().Add(-2 * chargerSwitchDuration)
+
+	tc := []struct {
+		name            string
+		status          api.ChargeStatus
+		lpEnabled       bool
+		chargerEnabled  bool
+		chargerSwitched time.Time
+		phasesSwitched  time.Time
+		expect          func(*api.MockCharger)
+		expEnabled      bool
+	}{
+		{"in sync enabled", api.StatusC, true, true, expired, expired, func(c *api.MockCharger) {}, true},
+		{"in sync disabled", api.StatusB, false, false, expired, expired, func(c *api.MockCharger) {}, false},
+		{"disabled during phase switch", api.StatusB, true, false, expired, recent, func(c *api.MockCharger) {
+			c.EXPECT().Enable(true).Return(nil)
+		}, true},
+		{"enabled externally after guard", api.StatusB, false, true, expired, expired, func(c *api.MockCharger) {}, true},
+		{"enabled externally within guard", api.StatusB, false, true, recent, expired, func(c *api.MockCharger) {}, false},
+		{"disabled externally after guard", api.StatusB, true, false, expired, expired, func(c *api.MockCharger) {}, false},
+		{"disabled externally within guard", api.StatusB, true, false, recent, expired, func(c *api.MockCharger) {}, true},
+		{"disabled but charging after guard", api.StatusC, false, false, expired, expired, func(c *api.MockCharger) {
+			c.EXPECT().Enable(true).Return(nil)
+		}, true},
+		{"disabled but charging within guard", api.StatusC, false, false, recent, expired, func(c *api.MockCharger) {}, false},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			charger := api.NewMockCharger(ctrl)
+
+			lp := newChargerTestLoadpoint(clock.NewMock(), charger)
+			lp.status = tc.status
+			lp.enabled = tc.lpEnabled
+			lp.chargerSwitched = tc.chargerSwitched
+			lp.phasesSwitched = tc.phasesSwitched
+
+			charger.EXPECT().Enabled().Return(tc.chargerEnabled, nil)
+			tc.expect(charger)
+
+			require.NoError(t, lp.syncCharger())
+			assert.Equal(t, tc.expEnabled, lp.enabled)
+		})
+	}
+}
+
+func TestSyncChargerErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+	lp := newChargerTestLoadpoint(clock.NewMock(), charger)
+
+	charger.EXPECT().Enabled().Return(false, errors.New("foo"))
+	assert.Error(t, lp.syncCharger())
+
+	lp.status = api.StatusC
+	lp.chargerSwitched = time.Now().Add(-2 * chargerSwitchDuration)
+
+	charger.EXPECT().Enabled().Return(false, nil)
+	charger.EXPECT().Enable(true).Return(errors.New("foo"))
+	assert.Error(t, lp.syncCharger())
+}
+
+func TestSyncChargerCurrent(t *testing.T) {
+	tc := []struct {
+		name             string
+		chargeCurrent    float64
+		chargerCurrent   float64
+		expChargeCurrent float64
+	}{
+		{"in sync", 10, 10, 10},
+		{"pwm rounding tolerated", 10, 10.2, 10},
+		{"mismatch", 10, 8, 8},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			charger := &currentGetterStub{MockCharger: api.NewMockCharger(ctrl), current: tc.chargerCurrent}
+
+			lp := newChargerTestLoadpoint(clock.NewMock(), charger)
+			lp.status = api.StatusC
+			lp.enabled = true
+			lp.chargeCurrent = tc.chargeCurrent
+
+			charger.EXPECT().Enabled().Return(true, nil)
+
+			require.NoError(t, lp.syncCharger())
+			assert.Equal(t, tc.expChargeCurrent, lp.chargeCurrent)
+		})
+	}
+}
+
+type rapidChargerStub struct {
+	*api.MockCharger
+}
+
+func (c *rapidChargerStub) Features() []api.Feature {
+	return []api.Feature{api.RapidCurrent}
+}
+
+func TestChargerUpdateCompletedRapid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+
+	lp := newChargerTestLoadpoint(clock.NewMock(), charger)
+	lp.chargerSwitched = time.Now().Add(-2 * rapidChargerSwitchDuration)
+	assert.False(t, lp.chargerUpdateCompleted())
+
+	lp.charger = &rapidChargerStub{charger}
+	assert.True(t, lp.chargerUpdateCompleted())
+}
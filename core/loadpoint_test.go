@@ -1,12 +1,15 @@
 package core
 
 import (
+	"context"
+	"math"
 	"testing"
 	"time"
 
 	evbus "github.com/asaskevich/EventBus"
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/soc"
 	"github.com/evcc-io/evcc/push"
 	"github.com/evcc-io/evcc/util"
@@ -80,7 +83,7 @@ func attachListeners(t *testing.T, lp *Loadpoint) {
 	}
 
 	uiChan, pushChan, lpChan := createChannels(t)
-	lp.Prepare(uiChan, pushChan, lpChan)
+	lp.Prepare(context.Background(), uiChan, pushChan, lpChan)
 }
 
 func TestNew(t *testing.T) {
@@ -763,3 +766,119 @@ func TestPVHysteresisAfterPhaseSwitch(t *testing.T) {
 		ctrl.Finish()
 	}
 }
+
+func TestSetMode(t *testing.T) {
+	lp := &Loadpoint{
+		log:   util.NewLogger("foo"),
+		clock: clock.NewMock(),
+		mode:  api.ModeOff,
+	}
+
+	for _, mode := range []api.ChargeMode{api.ModeEmpty, "foo"} {
+		assert.Error(t, lp.SetMode(mode), mode)
+		assert.Equal(t, api.ModeOff, lp.GetMode())
+	}
+
+	assert.NoError(t, lp.SetMode(api.ModePV))
+	assert.Equal(t, api.ModePV, lp.GetMode())
+
+	assert.NoError(t, lp.SetMode("Now"))
+	assert.Equal(t, api.ModeNow, lp.GetMode())
+}
+
+func TestSetLimitEnergy(t *testing.T) {
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		clock:         clock.NewMock(),
+		sessionEnergy: NewEnergyMetrics(),
+	}
+
+	assert.Error(t, lp.SetLimitEnergy(-1))
+	assert.Equal(t, 0.0, lp.GetLimitEnergy())
+
+	assert.NoError(t, lp.SetLimitEnergy(10))
+	assert.Equal(t, 10.0, lp.GetLimitEnergy())
+
+	remaining, ok := lp.remainingLimitEnergy()
+	assert.True(t, ok)
+	assert.Equal(t, 10.0, remaining)
+	assert.False(t, lp.limitEnergyReached())
+
+	assert.NoError(t, lp.SetLimitEnergy(0))
+	_, ok = lp.remainingLimitEnergy()
+	assert.False(t, ok)
+}
+
+func TestPublishLimitEnergyRemaining(t *testing.T) {
+	uiChan := make(chan util.Param, 16)
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		clock:         clock.NewMock(),
+		uiChan:        uiChan,
+		sessionEnergy: NewEnergyMetrics(),
+		limitEnergy:   10,
+	}
+
+	published := func() []any {
+		var res []any
+		for len(uiChan) > 0 {
+			if p := <-uiChan; p.Key == keys.LimitEnergyRemaining {
+				res = append(res, p.Val)
+			}
+		}
+		return res
+	}
+
+	lp.publishLimitEnergyRemaining()
+	assert.Equal(t, []any{10.0}, published())
+
+	// unchanged value is not published again
+	lp.publishLimitEnergyRemaining()
+	assert.Empty(t, published())
+
+	// removed limit publishes zero
+	lp.limitEnergy = 0
+	lp.publishLimitEnergyRemaining()
+	assert.Equal(t, []any{0.0}, published())
+}
+
+func FuzzPvMaxCurrent(f *testing.F) {
+	modes := []api.ChargeMode{api.ModeOff, api.ModeNow, api.ModeMinPV, api.ModePV}
+
+	for _, sitePower := range []float64{-1e9, 0, 1e9} {
+		for _, phases := range []int{0, 1, 3} {
+			for mode := range modes {
+				f.Add(sitePower, phases, uint8(mode), false, 0.0)
+				f.Add(sitePower, phases, uint8(mode), true, float64(maxA))
+			}
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, sitePower float64, phases int, mode uint8, enabled bool, chargeCurrent float64) {
+		// site power is a meter reading and never NaN or infinite
+		if math.IsNaN(sitePower) || math.IsInf(sitePower, 0) || math.IsNaN(chargeCurrent) || math.IsInf(chargeCurrent, 0) {
+			t.Skip()
+		}
+
+		phases = []int{0, 1, 2, 3}[uint(phases)%4]
+
+		lp := &Loadpoint{
+			log:           util.NewLogger("foo"),
+			clock:         clock.NewMock(),
+			minCurrent:    minA,
+			maxCurrent:    maxA,
+			phases:        phases,
+			status:        api.StatusC,
+			enabled:       enabled,
+			chargeCurrent: chargeCurrent,
+			Voltage:       230,
+		}
+
+		current := lp.pvMaxCurrent(modes[int(mode)%len(modes)], sitePower, false, false)
+
+		if !(current >= 0 && current <= lp.effectiveMaxCurrent()) {
+			t.Errorf("invalid current %.3gA for %.0fW @ %dp", current, sitePower, phases)
+		}
+	})
+}
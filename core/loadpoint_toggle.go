@@ -0,0 +1,47 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// toggleHoldDuration is the minimum charging duration once the toggle limit has been reached
+const toggleHoldDuration = 10 * time.Minute
+
+// pruneToggles removes charger toggles older than one hour
+func (lp *Loadpoint) pruneToggles() {
+	var i int
+	for i < len(lp.toggles) && lp.clock.Since(lp.toggles[i]) >= time.Hour {
+		i++
+	}
+	lp.toggles = lp.toggles[i:]
+}
+
+// recordToggle records a charger enable/disable transition
+func (lp *Loadpoint) recordToggle() {
+	if lp.MaxToggles <= 0 {
+		return
+	}
+
+	lp.pruneToggles()
+	lp.toggles = append(lp.toggles, lp.clock.Now())
+
+	lp.publish(keys.ToggleCount, len(lp.toggles))
+}
+
+// toggleLimitActive returns true if the charger must not be disabled yet due to exceeding the toggle limit
+func (lp *Loadpoint) toggleLimitActive() bool {
+	if lp.MaxToggles <= 0 {
+		return false
+	}
+
+	lp.pruneToggles()
+
+	res := len(lp.toggles) >= lp.MaxToggles && lp.clock.Since(lp.chargerSwitched) < toggleHoldDuration
+
+	lp.publish(keys.ToggleCount, len(lp.toggles))
+	lp.publish(keys.ToggleLimitActive, res)
+
+	return res
+}
@@ -0,0 +1,65 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToggleLimit(t *testing.T) {
+	clck := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:        util.NewLogger("foo"),
+		clock:      clck,
+		MaxToggles: 3,
+	}
+
+	toggle := func() {
+		lp.chargerSwitched = clck.Now()
+		lp.recordToggle()
+	}
+
+	toggle()
+	clck.Add(5 * time.Minute)
+	toggle()
+	assert.Len(t, lp.toggles, 2)
+	assert.False(t, lp.toggleLimitActive())
+
+	clck.Add(5 * time.Minute)
+	toggle()
+	assert.Len(t, lp.toggles, 3)
+	assert.True(t, lp.toggleLimitActive(), "limit reached")
+
+	clck.Add(toggleHoldDuration)
+	assert.False(t, lp.toggleLimitActive(), "hold duration elapsed")
+
+	clck.Add(10 * time.Minute)
+	toggle()
+	assert.True(t, lp.toggleLimitActive(), "limit still reached")
+
+	// first toggle expired after one hour
+	clck.Add(time.Hour - 30*time.Minute)
+	assert.Len(t, lp.toggles, 4)
+	assert.False(t, lp.toggleLimitActive(), "hold duration elapsed")
+	assert.Len(t, lp.toggles, 3)
+}
+
+func TestToggleLimitDisabled(t *testing.T) {
+	clck := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:   util.NewLogger("foo"),
+		clock: clck,
+	}
+
+	for range 10 {
+		lp.recordToggle()
+	}
+
+	assert.Empty(t, lp.toggles)
+	assert.False(t, lp.toggleLimitActive())
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/evcc-io/evcc/core/session"
 	"github.com/evcc-io/evcc/core/soc"
 	"github.com/evcc-io/evcc/core/vehicle"
+	"github.com/evcc-io/evcc/core/wrapper"
 	"github.com/evcc-io/evcc/provider"
 )
 
@@ -25,7 +26,15 @@ func (lp *Loadpoint) coordinatedVehicles() []api.Vehicle {
 	if lp.coordinator == nil {
 		return nil
 	}
-	return lp.coordinator.GetVehicles()
+
+	res := lp.coordinator.GetVehicles()
+	if len(lp.vehicles) > 0 {
+		res = slices.DeleteFunc(res, func(v api.Vehicle) bool {
+			return !slices.Contains(lp.vehicles, v)
+		})
+	}
+
+	return res
 }
 
 // setVehicleIdentifier updated the vehicle id as read from the charger
@@ -124,6 +133,17 @@ func (lp *Loadpoint) setActiveVehicle(v api.Vehicle) {
 	if v != nil {
 		lp.socUpdated = time.Time{}
 
+		// detected max current may be limited by the previous vehicle
+		lp.resetMaxCurrentDetection(v)
+
+		// restored session state must belong to the identified vehicle
+		if cr, ok := lp.chargeRater.(*wrapper.ChargeRater); ok {
+			cr.SetVehicle(v.Title())
+		}
+		if ct, ok := lp.chargeTimer.(*wrapper.ChargeTimer); ok {
+			ct.SetVehicle(v.Title())
+		}
+
 		// resolve optional config
 		var estimate bool
 		if lp.Soc.Estimate == nil || *lp.Soc.Estimate {
@@ -134,7 +154,9 @@ func (lp *Loadpoint) setActiveVehicle(v api.Vehicle) {
 		lp.publish(keys.VehicleName, vehicle.Settings(lp.log, v).Name())
 
 		if mode, ok := v.OnIdentified().GetMode(); ok {
-			lp.SetMode(mode)
+			if err := lp.SetMode(mode); err != nil {
+				lp.log.ERROR.Println(err)
+			}
 		}
 
 		lp.addTask(lp.vehicleOdometer)
@@ -177,21 +199,54 @@ func (lp *Loadpoint) wakeUpVehicle() {
 		// vehicle
 		if vs, ok := lp.GetVehicle().(api.Resurrector); ok {
 			lp.log.DEBUG.Printf("wake-up vehicle, attempts left: %d", lp.wakeUpTimer.wakeupAttemptsLeft)
-			if err := vs.WakeUp(); err != nil {
+			if err := lp.vehicleCommand("wake-up vehicle", vs.WakeUp); err != nil {
 				lp.log.ERROR.Printf("wake-up vehicle: %v", err)
 			}
 		}
 	}
 }
 
+// getVehicleCurrentLimit returns the vehicle charge current limit
+func (lp *Loadpoint) getVehicleCurrentLimit() int64 {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.vehicleCurrentLimit
+}
+
+// updateVehicleCurrentLimit reads the charge current limit configured in the vehicle
+func (lp *Loadpoint) updateVehicleCurrentLimit() {
+	vl, ok := lp.GetVehicle().(api.VehicleCurrentLimit)
+	if !ok {
+		return
+	}
+
+	limit, err := vl.ChargeCurrentLimit()
+	if err != nil {
+		if !errors.Is(err, api.ErrNotAvailable) {
+			lp.log.ERROR.Printf("vehicle current limit: %v", err)
+		}
+		return
+	}
+
+	lp.log.DEBUG.Printf("vehicle current limit: %dA", limit)
+
+	lp.Lock()
+	lp.vehicleCurrentLimit = limit
+	lp.Unlock()
+
+	lp.publish(keys.VehicleCurrentLimit, limit)
+}
+
 // unpublishVehicle resets published vehicle data
 func (lp *Loadpoint) unpublishVehicle() {
 	lp.vehicleSoc = 0
+	lp.vehicleCurrentLimit = 0
 
 	lp.publish(keys.VehicleClimaterActive, nil)
 	lp.publish(keys.VehicleSoc, 0.0)
 	lp.publish(keys.VehicleRange, int64(0))
 	lp.publish(keys.VehicleTargetSoc, 0.0)
+	lp.publish(keys.VehicleCurrentLimit, int64(0))
 
 	lp.setRemainingEnergy(0)
 	lp.setRemainingDuration(0)
@@ -206,6 +261,14 @@ func (lp *Loadpoint) vehicleHasFeature(f api.Feature) bool {
 	return ok
 }
 
+// vehicleCapacity returns the active vehicle's capacity in kWh or zero if no vehicle is active
+func (lp *Loadpoint) vehicleCapacity() float64 {
+	if v := lp.GetVehicle(); v != nil {
+		return v.Capacity()
+	}
+	return 0
+}
+
 // vehicleUnidentified returns true if there are associated vehicles and detection is running.
 // It will also reset the api cache at regular intervals.
 // Detection is stopped after maximum duration and the "guest vehicle" message dispatched.
@@ -217,6 +280,14 @@ func (lp *Loadpoint) vehicleUnidentified() bool {
 	// stop detection
 	if lp.clock.Since(lp.vehicleDetect) > vehicleDetectDuration {
 		lp.stopVehicleDetection()
+
+		// fall back to the first configured vehicle
+		if len(lp.vehicles) > 0 {
+			lp.log.WARN.Printf("vehicle not identified, using %s", lp.vehicles[0].Title())
+			lp.setActiveVehicle(lp.vehicles[0])
+			return false
+		}
+
 		lp.pushEvent(evVehicleUnidentified)
 		return false
 	}
@@ -273,7 +344,7 @@ func (lp *Loadpoint) identifyVehicleByStatus() {
 		return
 	}
 
-	if vehicle := lp.coordinator.IdentifyVehicleByStatus(); vehicle != nil {
+	if vehicle := lp.coordinator.IdentifyVehicleByStatus(); vehicle != nil && (len(lp.vehicles) == 0 || slices.Contains(lp.vehicles, vehicle)) {
 		lp.stopVehicleDetection()
 		lp.setActiveVehicle(vehicle)
 		return
@@ -316,9 +387,10 @@ func (lp *Loadpoint) vehicleClimatePollAllowed() bool {
 
 // vehicleSocPollAllowed validates charging state against polling mode
 func (lp *Loadpoint) vehicleSocPollAllowed() bool {
-	// always update soc when charging
+	// update soc when charging, optionally limited to charging interval
 	if lp.charging() {
-		return true
+		return lp.Soc.Poll.ChargingInterval <= 0 || lp.socUpdated.IsZero() ||
+			lp.clock.Since(lp.socUpdated) >= lp.Soc.Poll.ChargingInterval
 	}
 
 	// update if connected and soc unknown
@@ -292,3 +292,57 @@ func TestReconnectVehicle(t *testing.T) {
 		})
 	}
 }
+
+func TestVehiclesFallback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clck := clock.NewMock()
+
+	v1 := api.NewMockVehicle(ctrl)
+	v2 := api.NewMockVehicle(ctrl)
+	other := api.NewMockVehicle(ctrl)
+	expectVehiclePublish(v1)
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.clock = clck
+	lp.vehicles = []api.Vehicle{v1, v2}
+	lp.coordinator = coordinator.NewAdapter(lp, coordinator.New(util.NewLogger("foo"), []api.Vehicle{other, v2, v1}))
+
+	x, y, z := createChannels(t)
+	attachChannels(lp, x, y, z)
+
+	// only configured vehicles are identified
+	assert.Equal(t, []api.Vehicle{v2, v1}, lp.coordinatedVehicles())
+
+	lp.startVehicleDetection()
+	assert.True(t, lp.vehicleUnidentified())
+
+	// first vehicle after detection timeout
+	clck.Add(vehicleDetectDuration + time.Second)
+	assert.False(t, lp.vehicleUnidentified())
+	assert.Equal(t, v1, lp.GetVehicle())
+}
+
+func TestNoVehicle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := &Loadpoint{
+		log:        util.NewLogger("foo"),
+		clock:      clock.NewMock(),
+		vehicleSoc: 50,
+	}
+
+	// all vehicle helpers must be safe without active vehicle
+	assert.Zero(t, lp.vehicleCapacity())
+	assert.False(t, lp.vehicleHasSoc())
+	assert.False(t, lp.socBasedPlanning())
+	assert.False(t, lp.minSocNotReached())
+	assert.False(t, lp.vehicleHasFeature(api.Offline))
+
+	vehicle := api.NewMockVehicle(ctrl)
+	vehicle.EXPECT().Capacity().Return(50.0).AnyTimes()
+	vehicle.EXPECT().Features().AnyTimes()
+	lp.vehicle = vehicle
+
+	assert.Equal(t, 50.0, lp.vehicleCapacity())
+	assert.True(t, lp.socBasedPlanning())
+}
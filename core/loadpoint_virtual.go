@@ -0,0 +1,44 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/soc"
+)
+
+// resetVirtualSoc restarts the soc estimation of vehicles without api from the configured initial soc
+func (lp *Loadpoint) resetVirtualSoc() {
+	lp.virtualSocUpdated = time.Time{}
+
+	if vs, ok := lp.GetVehicle().(api.SocEstimate); ok {
+		vs.ResetSoc()
+	}
+}
+
+// updateVirtualSoc adds the energy charged since the last update to the soc of vehicles without api
+func (lp *Loadpoint) updateVirtualSoc() {
+	v := lp.GetVehicle()
+
+	vs, ok := v.(api.SocEstimate)
+	if !ok || !lp.charging() || v.Capacity() <= 0 {
+		lp.virtualSocUpdated = time.Time{}
+		return
+	}
+
+	now := lp.clock.Now()
+	defer func() { lp.virtualSocUpdated = now }()
+
+	if lp.virtualSocUpdated.IsZero() {
+		return
+	}
+
+	f, err := v.Soc()
+	if err != nil {
+		lp.log.ERROR.Printf("vehicle soc: %v", err)
+		return
+	}
+
+	energy := lp.chargePower * now.Sub(lp.virtualSocUpdated).Hours() * soc.ChargeEfficiency // Wh
+	vs.SetSoc(f + 100*energy/(1e3*v.Capacity()))
+}
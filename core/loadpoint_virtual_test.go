@@ -0,0 +1,100 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/vehicle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVirtualSoc(t *testing.T) {
+	clck := clock.NewMock()
+
+	_, err := vehicle.NewVirtualFromConfig(map[string]interface{}{})
+	assert.Error(t, err, "missing capacity")
+
+	v, err := vehicle.NewVirtualFromConfig(map[string]interface{}{"capacity": 60})
+	require.NoError(t, err)
+
+	lp := &Loadpoint{
+		log:         util.NewLogger("foo"),
+		clock:       clck,
+		vehicle:     v,
+		status:      api.StatusC,
+		chargePower: 10000,
+	}
+
+	soc := func() float64 {
+		f, err := v.Soc()
+		require.NoError(t, err)
+		return f
+	}
+
+	// first update starts estimation
+	lp.updateVirtualSoc()
+	assert.Equal(t, 0.0, soc())
+
+	// 10kWh at 90% efficiency into 60kWh
+	clck.Add(time.Hour)
+	lp.updateVirtualSoc()
+	assert.InDelta(t, 15.0, soc(), 1e-9)
+
+	// not charging keeps soc
+	lp.status = api.StatusB
+	clck.Add(time.Hour)
+	lp.updateVirtualSoc()
+	assert.InDelta(t, 15.0, soc(), 1e-9)
+
+	// restart continues from last known soc
+	lp.status = api.StatusC
+	lp.updateVirtualSoc()
+	clck.Add(30 * time.Minute)
+	lp.updateVirtualSoc()
+	assert.InDelta(t, 22.5, soc(), 1e-9)
+
+	// soc is capped
+	clck.Add(24 * time.Hour)
+	lp.updateVirtualSoc()
+	assert.Equal(t, 100.0, soc())
+}
+
+func TestVirtualSocReset(t *testing.T) {
+	clck := clock.NewMock()
+
+	_, err := vehicle.NewVirtualFromConfig(map[string]interface{}{"capacity": 60, "soc": 120})
+	assert.Error(t, err, "invalid soc")
+
+	v, err := vehicle.NewVirtualFromConfig(map[string]interface{}{"capacity": 60, "soc": 20})
+	require.NoError(t, err)
+
+	lp := &Loadpoint{
+		log:         util.NewLogger("foo"),
+		clock:       clck,
+		vehicle:     v,
+		status:      api.StatusC,
+		chargePower: 10000,
+	}
+
+	soc := func() float64 {
+		f, err := v.Soc()
+		require.NoError(t, err)
+		return f
+	}
+
+	assert.Equal(t, 20.0, soc())
+
+	lp.updateVirtualSoc()
+	clck.Add(time.Hour)
+	lp.updateVirtualSoc()
+	assert.InDelta(t, 35.0, soc(), 1e-9)
+
+	// reconnecting restarts from the configured soc
+	lp.resetVirtualSoc()
+	assert.Equal(t, 20.0, soc())
+	assert.True(t, lp.virtualSocUpdated.IsZero())
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+const (
+	boostVoltage   = 240 // V
+	nominalVoltage = 230 // V
+
+	underVoltageRatio = 0.9 // charge power below expected minimum power indicating under-voltage
+)
+
+// updateVoltageBoost requests a higher supply voltage while mandatory charging is active and under-performing
+func (lp *Loadpoint) updateVoltageBoost() {
+	vc, ok := lp.charger.(api.VoltageController)
+	if !ok {
+		return
+	}
+
+	lp.voltageBoost(vc, lp.minSocNotReached())
+}
+
+// voltageBoost requests boost voltage if charging with less than expected power and restores nominal voltage once mandatory charging has finished
+func (lp *Loadpoint) voltageBoost(vc api.VoltageController, mandatory bool) {
+	var voltage float64
+
+	switch {
+	case !lp.voltageBoostActive && mandatory && lp.charging():
+		minPower := lp.effectiveMinCurrent() * float64(lp.ActivePhases()) * lp.effectiveVoltage()
+		if lp.chargePower >= minPower*underVoltageRatio {
+			return
+		}
+
+		lp.log.INFO.Printf("under-voltage detected: charge power %.0fW < %.0fW", lp.chargePower, minPower)
+		voltage = boostVoltage
+
+	case lp.voltageBoostActive && !mandatory:
+		voltage = nominalVoltage
+
+	default:
+		return
+	}
+
+	if err := vc.SetVoltage(voltage); err != nil {
+		lp.log.ERROR.Printf("set voltage %.0fV: %v", voltage, err)
+		return
+	}
+
+	lp.log.INFO.Printf("set voltage: %.0fV", voltage)
+
+	lp.voltageBoostActive = voltage == boostVoltage
+	lp.publish(keys.VoltageBoostActive, lp.voltageBoostActive)
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVoltageBoost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	vc := api.NewMockVoltageController(ctrl)
+
+	Voltage = 230 // V
+
+	lp := &Loadpoint{
+		log:        util.NewLogger("foo"),
+		clock:      clock.NewMock(),
+		charger:    api.NewMockCharger(ctrl),
+		status:     api.StatusC,
+		phases:     1,
+		minCurrent: 6,
+	}
+
+	// sufficient power
+	lp.chargePower = 6 * 230
+	lp.voltageBoost(vc, true)
+	assert.False(t, lp.voltageBoostActive)
+
+	// not mandatory
+	lp.chargePower = 1000
+	lp.voltageBoost(vc, false)
+	assert.False(t, lp.voltageBoostActive)
+
+	// under-voltage while mandatory charging
+	gomock.InOrder(
+		vc.EXPECT().SetVoltage(float64(boostVoltage)).Return(nil),
+		vc.EXPECT().SetVoltage(float64(nominalVoltage)).Return(nil),
+	)
+
+	lp.voltageBoost(vc, true)
+	assert.True(t, lp.voltageBoostActive)
+
+	// no repeated requests
+	lp.voltageBoost(vc, true)
+	assert.True(t, lp.voltageBoostActive)
+
+	// restore after mandatory charging
+	lp.voltageBoost(vc, false)
+	assert.False(t, lp.voltageBoostActive)
+}
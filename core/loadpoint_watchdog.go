@@ -0,0 +1,77 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// feedWatchdog notifies the watchdog that the loadpoint has been updated
+func (lp *Loadpoint) feedWatchdog() {
+	if lp.watchdogC == nil {
+		return
+	}
+
+	select {
+	case lp.watchdogC <- struct{}{}:
+	default:
+	}
+}
+
+// watchdog disables the charger if the loadpoint is not updated within the watchdog timeout,
+// flags the timeout for the update loop and requests an update.
+// It stops when the context is cancelled.
+func (lp *Loadpoint) watchdog(ctx context.Context) {
+	timer := lp.clock.Timer(lp.WatchdogTimeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-lp.watchdogC:
+			timer.Reset(lp.WatchdogTimeout)
+
+		case <-timer.C:
+			lp.watchdogTripped.Store(true)
+			lp.watchdogDisable()
+			lp.requestUpdate()
+		}
+	}
+}
+
+// watchdogDisable disables the charger without relying on the stalled update loop
+func (lp *Loadpoint) watchdogDisable() {
+	lp.chargerMu.Lock()
+	defer lp.chargerMu.Unlock()
+
+	if err := lp.charger.Enable(false); err != nil {
+		lp.log.ERROR.Printf("watchdog: charger disable: %v", err)
+		return
+	}
+
+	lp.enabled = false
+}
+
+// watchdogExpired returns true once after the watchdog has timed out and publishes the watchdog status.
+// Must only be called from the update loop.
+func (lp *Loadpoint) watchdogExpired() bool {
+	expired := lp.watchdogTripped.Swap(false)
+
+	switch {
+	case expired:
+		err := fmt.Errorf("watchdog: no update within %v, disabling charger", lp.WatchdogTimeout)
+		lp.log.ERROR.Println(err)
+		lp.publish(keys.Watchdog, err.Error())
+
+	case lp.watchdogError:
+		lp.log.INFO.Println("watchdog: updates resumed")
+		lp.publish(keys.Watchdog, nil)
+	}
+
+	lp.watchdogError = expired
+
+	return expired
+}
@@ -0,0 +1,154 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWatchdog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clck := clock.NewMock()
+	charger := api.NewMockCharger(ctrl)
+	lpChan := make(chan *Loadpoint, 1)
+
+	lp := &Loadpoint{
+		log:             util.NewLogger("foo"),
+		clock:           clck,
+		lpChan:          lpChan,
+		charger:         charger,
+		enabled:         true,
+		WatchdogTimeout: time.Minute,
+	}
+
+	// unbuffered to synchronize with the watchdog loop
+	lp.watchdogC = make(chan struct{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+
+	go func() {
+		lp.watchdog(ctx)
+		close(stopped)
+	}()
+
+	// the second send returns after the first one has been handled
+	feed := func() {
+		lp.watchdogC <- struct{}{}
+		lp.watchdogC <- struct{}{}
+	}
+
+	feed()
+
+	// updated in time
+	clck.Add(59 * time.Second)
+	feed()
+	clck.Add(59 * time.Second)
+
+	assert.False(t, lp.watchdogTripped.Load())
+	assert.Empty(t, lpChan)
+
+	// timeout disables charger without the update loop and requests update
+	charger.EXPECT().Enable(false).Return(nil)
+	clck.Add(time.Second)
+
+	select {
+	case <-lpChan:
+	case <-time.After(time.Second):
+		require.Fail(t, "update not requested")
+	}
+
+	assert.True(t, lp.watchdogTripped.Load())
+
+	lp.chargerMu.Lock()
+	assert.False(t, lp.enabled)
+	lp.chargerMu.Unlock()
+
+	cancel()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		require.Fail(t, "watchdog not stopped")
+	}
+}
+
+func TestWatchdogUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clck := clock.NewMock()
+	charger := api.NewMockCharger(ctrl)
+	uiChan := make(chan util.Param, 100)
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		bus:           evbus.New(),
+		clock:         clck,
+		charger:       charger,
+		chargeMeter:   &Null{}, // silence nil panics
+		chargeRater:   &Null{}, // silence nil panics
+		chargeTimer:   &Null{}, // silence nil panics
+		wakeUpTimer:   NewTimer(),
+		sessionEnergy: NewEnergyMetrics(),
+		minCurrent:    minA,
+		maxCurrent:    maxA,
+		status:        api.StatusC,
+		enabled:       true,
+		chargeCurrent: maxA,
+		mode:          api.ModeNow,
+		Mode_:         api.ModeNow, // default mode
+	}
+
+	attachListeners(t, lp)
+
+	// watchdog goroutine is not started, timeout is signalled manually
+	lp.uiChan = uiChan
+	lp.WatchdogTimeout = time.Minute
+
+	published := func() (any, bool) {
+		var res any
+		var ok bool
+		for {
+			select {
+			case p := <-uiChan:
+				if p.Key == keys.Watchdog {
+					res, ok = p.Val, true
+				}
+			default:
+				return res, ok
+			}
+		}
+	}
+
+	// timeout disables charger
+	lp.watchdogTripped.Store(true)
+	charger.EXPECT().Enabled().Return(lp.enabled, nil)
+	charger.EXPECT().Status().Return(api.StatusC, nil)
+	charger.EXPECT().Enable(false).Return(nil)
+	lp.Update(0, false, false, false, 0, nil, nil)
+
+	assert.False(t, lp.enabled)
+	val, ok := published()
+	assert.True(t, ok)
+	assert.NotNil(t, val)
+
+	// resumed updates clear the error and charge again
+	clck.Add(time.Minute)
+	charger.EXPECT().Enabled().Return(lp.enabled, nil)
+	charger.EXPECT().Status().Return(api.StatusB, nil)
+	charger.EXPECT().MaxCurrent(int64(maxA)).Return(nil).AnyTimes()
+	charger.EXPECT().Enable(true).Return(nil).AnyTimes()
+	lp.Update(0, false, false, false, 0, nil, nil)
+
+	val, ok = published()
+	assert.True(t, ok)
+	assert.Nil(t, val)
+}
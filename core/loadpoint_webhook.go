@@ -0,0 +1,53 @@
+package core
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/evcc-io/evcc/util/request"
+)
+
+// socWebhookPayload is posted to the soc webhook on every successful vehicle soc read
+type socWebhookPayload struct {
+	Loadpoint string    `json:"loadpoint"`
+	Soc       float64   `json:"soc"`
+	Timestamp time.Time `json:"ts"`
+}
+
+// postSocWebhook sends the payload to the soc webhook, retrying once on failure
+func (lp *Loadpoint) postSocWebhook(uri string, payload socWebhookPayload) error {
+	helper := request.NewHelper(lp.log)
+
+	var err error
+	for range 2 {
+		var req *http.Request
+		if req, err = request.New(http.MethodPost, uri, request.MarshalJSON(payload), request.JSONEncoding); err != nil {
+			return err
+		}
+
+		if _, err = helper.DoBody(req); err == nil {
+			break
+		}
+	}
+
+	return err
+}
+
+// publishSocWebhook posts the vehicle soc to the soc webhook without blocking
+func (lp *Loadpoint) publishSocWebhook(soc float64) {
+	if lp.SocWebhookURL == "" {
+		return
+	}
+
+	payload := socWebhookPayload{
+		Loadpoint: lp.Title(),
+		Soc:       soc,
+		Timestamp: lp.clock.Now(),
+	}
+
+	go func(uri string) {
+		if err := lp.postSocWebhook(uri, payload); err != nil {
+			lp.log.WARN.Printf("soc webhook: %v", err)
+		}
+	}(lp.SocWebhookURL)
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSocWebhook(t *testing.T) {
+	var attempts int
+	bodies := make(chan socWebhookPayload, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var res socWebhookPayload
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&res))
+		bodies <- res
+	}))
+	defer srv.Close()
+
+	clck := clock.NewMock()
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		clock:         clck,
+		Title_:        "Garage",
+		SocWebhookURL: srv.URL,
+	}
+
+	lp.publishSocWebhook(75.2)
+
+	select {
+	case res := <-bodies:
+		assert.Equal(t, "Garage", res.Loadpoint)
+		assert.Equal(t, 75.2, res.Soc)
+		assert.True(t, clck.Now().Equal(res.Timestamp))
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "webhook not received")
+	}
+
+	assert.Equal(t, 2, attempts)
+}
+
+func TestSocWebhookNonBlocking(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		clock:         clock.NewMock(),
+		SocWebhookURL: srv.URL,
+	}
+
+	done := make(chan struct{})
+	go func() {
+		lp.publishSocWebhook(50)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.Fail(t, "webhook blocked")
+	}
+
+	// no webhook configured
+	lp.SocWebhookURL = ""
+	lp.publishSocWebhook(50)
+}
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _ prometheus.Collector = (*Collector)(nil)
+
+// Collector exposes published loadpoint values as prometheus metrics
+type Collector struct {
+	mu             sync.Mutex
+	powerDesc      *prometheus.Desc
+	energyDesc     *prometheus.Desc
+	durationDesc   *prometheus.Desc
+	statusDesc     *prometheus.Desc
+	chargePower    float64
+	chargedEnergy  float64
+	chargeDuration time.Duration
+	connected      bool
+	charging       bool
+}
+
+// New creates a collector for the given loadpoint
+func New(loadpoint string) *Collector {
+	labels := prometheus.Labels{"loadpoint": loadpoint}
+
+	return &Collector{
+		powerDesc:    prometheus.NewDesc("evcc_charge_power_watts", "Loadpoint charge power", nil, labels),
+		energyDesc:   prometheus.NewDesc("evcc_charged_energy_wh", "Loadpoint session charged energy", nil, labels),
+		durationDesc: prometheus.NewDesc("evcc_charge_duration_seconds", "Loadpoint session charge duration", nil, labels),
+		statusDesc:   prometheus.NewDesc("evcc_charger_status", "Loadpoint charger status, 1 for the active status", []string{"status"}, labels),
+	}
+}
+
+// Update records a published loadpoint value. Unrelated keys are ignored.
+func (c *Collector) Update(key string, val any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch key {
+	case keys.ChargePower:
+		if v, ok := val.(float64); ok {
+			c.chargePower = v
+		}
+	case keys.ChargedEnergy:
+		if v, ok := val.(float64); ok {
+			c.chargedEnergy = v
+		}
+	case keys.ChargeDuration:
+		if v, ok := val.(time.Duration); ok {
+			c.chargeDuration = v
+		}
+	case keys.Connected:
+		if v, ok := val.(bool); ok {
+			c.connected = v
+		}
+	case keys.Charging:
+		if v, ok := val.(bool); ok {
+			c.charging = v
+		}
+	}
+}
+
+// status returns the charger status derived from the connected and charging state
+func (c *Collector) status() api.ChargeStatus {
+	switch {
+	case c.charging:
+		return api.StatusC
+	case c.connected:
+		return api.StatusB
+	default:
+		return api.StatusA
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.powerDesc
+	ch <- c.energyDesc
+	ch <- c.durationDesc
+	ch <- c.statusDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch <- prometheus.MustNewConstMetric(c.powerDesc, prometheus.GaugeValue, c.chargePower)
+	ch <- prometheus.MustNewConstMetric(c.energyDesc, prometheus.GaugeValue, c.chargedEnergy)
+	ch <- prometheus.MustNewConstMetric(c.durationDesc, prometheus.GaugeValue, c.chargeDuration.Seconds())
+
+	status := c.status()
+	for _, s := range []api.ChargeStatus{api.StatusA, api.StatusB, api.StatusC} {
+		var v float64
+		if s == status {
+			v = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.statusDesc, prometheus.GaugeValue, v, string(s))
+	}
+}
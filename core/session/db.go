@@ -53,6 +53,13 @@ func (s *DB) Sessions() (Sessions, error) {
 	return res, tx.Error
 }
 
+// LoadpointSessions returns the sessions of the store's loadpoint
+func (s *DB) LoadpointSessions() (Sessions, error) {
+	var res Sessions
+	tx := s.db.Order("ID").Find(&res, "Loadpoint = ?", s.name)
+	return res, tx.Error
+}
+
 func (s *DB) ClosePendingSessionsInHistory(chargeMeterTotal float64) error {
 	var res Sessions
 	if tx := s.db.Find(&res, map[string]interface{}{"finished": "0001-01-01 00:00:00+00:00", "Loadpoint": s.name}); tx.Error != nil {
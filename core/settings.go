@@ -105,3 +105,19 @@ func (s *Settings) Bool(key string) (bool, error) {
 // func (s *Settings) Json(key string, res any) error {
 // 	return settings.Json(s.Key+key, &res)
 // }
+
+// Save implements wrapper.StateStore
+func (s *Settings) Save(key string, val any) error {
+	if s == nil {
+		return nil
+	}
+	return settings.SetJson(s.Key+key, val)
+}
+
+// Load implements wrapper.StateStore
+func (s *Settings) Load(key string, res any) error {
+	if s == nil {
+		return settings.ErrNotFound
+	}
+	return settings.Json(s.Key+key, res)
+}
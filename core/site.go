@@ -28,6 +28,7 @@ import (
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/config"
 	"github.com/evcc-io/evcc/util/telemetry"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/smallnest/chanx"
 )
 
@@ -37,6 +38,7 @@ const standbyPower = 10 // consider less than 10W as charger in standby
 type updater interface {
 	loadpoint.API
 	Update(availablePower float64, autoCharge, batteryBuffered, batteryStart bool, greenShare float64, effectivePrice, effectiveCo2 *float64)
+	meterOfflineFallback(offline time.Duration)
 }
 
 // meterMeasurement is used as slice element for publishing structured data
@@ -71,11 +73,24 @@ type Site struct {
 	Meters                            MetersConfig // Meter references
 	MaxGridSupplyWhileBatteryCharging float64      `mapstructure:"maxGridSupplyWhileBatteryCharging"` // ignore battery charging if AC consumption is above this value
 
+	FuseRating         float64 `mapstructure:"fuseRating"`         // Shared fuse current per phase, 0 = unlimited
+	GridCurrentReserve float64 `mapstructure:"gridCurrentReserve"` // Fuse current per phase reserved for non-loadpoint consumers
+
+	MaxGridPower float64 `mapstructure:"maxGridPower"` // Maximum grid import shared by loadpoints in W, 0 = unlimited
+
+	SitePowerInterpretation string `mapstructure:"sitePowerInterpretation"` // grid (default) or pv_minus_load
+
+	BaseEnableThreshold float64 `mapstructure:"baseEnableThreshold"` // PV mode enable threshold at full forecast confidence
+	MaxForecastBias     float64 `mapstructure:"maxForecastBias"`     // Relative enable threshold increase at zero forecast confidence
+
+	GridFrequencyMeter string `mapstructure:"gridFrequencyMeter"` // Grid frequency meter for gridfriendly mode
+
 	// meters
 	gridMeter     api.Meter   // Grid usage meter
 	pvMeters      []api.Meter // PV generation meters
 	batteryMeters []api.Meter // Battery charging meters
 	auxMeters     []api.Meter // Auxiliary meters
+	houseMeter    api.Meter   // House consumption meter
 
 	// battery settings
 	prioritySoc             float64 // prefer battery up to this Soc
@@ -96,6 +111,17 @@ type Site struct {
 	batterySoc   float64         // Battery soc
 	batteryMode  api.BatteryMode // Battery mode
 
+	// pv forecast
+	forecastAdjusted     time.Time // Day of last enable threshold adjustment
+	forecastReference    float64   // Highest daily forecast energy in Wh, decaying daily
+	forecastReferenceDay time.Time // Day of last forecast reference decay
+
+	// pv fairness
+	fairnessUpdated time.Time // Last fairness accounting
+
+	// site meter offline fallback
+	sitePowerFailed time.Time // First failed site power update of current outage
+
 	publishCache map[string]any // store last published values to avoid unnecessary republishing
 }
 
@@ -105,6 +131,7 @@ type MetersConfig struct {
 	PVMetersRef      []string `mapstructure:"pv"`      // PV meter
 	BatteryMetersRef []string `mapstructure:"battery"` // Battery charging meter
 	AuxMetersRef     []string `mapstructure:"aux"`     // Auxiliary meters
+	HouseMeterRef    string   `mapstructure:"house"`   // House consumption meter excluding loadpoints
 }
 
 // NewSiteFromConfig creates a new site
@@ -143,6 +170,7 @@ func NewSiteFromConfig(
 	for _, lp := range loadpoints {
 		lp.coordinator = coordinator.NewAdapter(lp, site.coordinator)
 		lp.planner = planner.New(lp.log, tariff)
+		lp.co2Tariff = site.GetTariff(Co2Tariff)
 
 		if db.Instance != nil {
 			var err error
@@ -202,6 +230,42 @@ func NewSiteFromConfig(
 		site.auxMeters = append(site.auxMeters, dev.Instance())
 	}
 
+	// house meter
+	if site.Meters.HouseMeterRef != "" {
+		dev, err := config.Meters().ByName(site.Meters.HouseMeterRef)
+		if err != nil {
+			return nil, err
+		}
+		site.houseMeter = dev.Instance()
+	}
+
+	// grid frequency meter
+	if site.GridFrequencyMeter != "" {
+		dev, err := config.Meters().ByName(site.GridFrequencyMeter)
+		if err != nil {
+			return nil, err
+		}
+
+		fm, ok := dev.Instance().(api.FrequencyMeter)
+		if !ok {
+			return nil, fmt.Errorf("grid frequency meter %s does not provide frequency", site.GridFrequencyMeter)
+		}
+
+		for _, lp := range loadpoints {
+			lp.gridFrequencyMeter = fm
+		}
+	}
+
+	switch site.SitePowerInterpretation {
+	case "", sitePowerGrid:
+	case sitePowerPvMinusLoad:
+		if site.houseMeter == nil || len(site.pvMeters) == 0 {
+			return nil, fmt.Errorf("site power interpretation %s requires house and pv meters", sitePowerPvMinusLoad)
+		}
+	default:
+		return nil, fmt.Errorf("invalid site power interpretation: %s", site.SitePowerInterpretation)
+	}
+
 	// configure meter from references
 	if site.gridMeter == nil && len(site.pvMeters) == 0 {
 		return nil, errors.New("missing either grid or pv meter")
@@ -646,6 +710,15 @@ func (site *Site) sitePower(totalChargePower, flexiblePower float64) (float64, b
 
 	sitePower := sitePower(site.log, site.MaxGridSupplyWhileBatteryCharging, site.gridPower, batteryPower, site.ResidualPower)
 
+	// derive surplus from pv production and house consumption instead of grid power
+	if site.SitePowerInterpretation == sitePowerPvMinusLoad {
+		if housePower, err := site.updateHouseMeter(); err == nil {
+			sitePower = pvMinusLoadPower(site.pvPower, housePower, totalChargePower, site.batteryPower-batteryPower, site.ResidualPower)
+		} else {
+			site.log.ERROR.Printf("house meter: %v", err)
+		}
+	}
+
 	// deduct smart loads
 	if len(site.auxMeters) > 0 {
 		var auxPower float64
@@ -755,13 +828,27 @@ func (site *Site) update(lp updater) {
 
 	// update all loadpoint's charge power
 	var totalChargePower float64
+	var connectedVehicles int
 	for _, lp := range site.loadpoints {
 		lp.UpdateChargePower()
 		totalChargePower += lp.GetChargePower()
 
+		if lp.connected() {
+			connectedVehicles++
+		}
+
 		site.prioritizer.UpdateChargePowerFlexibility(lp)
 	}
 
+	site.publish(keys.TotalChargePower, totalChargePower)
+	site.publish(keys.ConnectedVehicles, connectedVehicles)
+
+	// share fuse current between loadpoints
+	site.allocateFuseCurrent()
+
+	// adjust pv enable thresholds to forecast quality
+	site.adjustEnableThresholds(time.Now())
+
 	// prioritize if possible
 	var flexiblePower float64
 	if lp.GetMode() == api.ModePV {
@@ -774,10 +861,20 @@ func (site *Site) update(lp updater) {
 	}
 
 	if sitePower, batteryBuffered, batteryStart, err := site.sitePower(totalChargePower, flexiblePower); err == nil {
+		// site meter recovered
+		site.sitePowerFailed = time.Time{}
+		lp.meterOfflineFallback(0)
+
+		// share grid power between loadpoints based on the current grid measurement
+		site.allocateGridPower(site.gridPower, totalChargePower)
+
 		// ignore negative pvPower values as that means it is not an energy source but consumption
 		homePower := site.gridPower + max(0, site.pvPower) + site.batteryPower - totalChargePower
 		homePower = max(homePower, 0)
 		site.publish(keys.HomePower, homePower)
+		flow := energyFlow(site.pvPower, site.gridPower, site.batteryPower, totalChargePower)
+		site.publish(keys.EnergyFlow, flow)
+		site.updatePvFairness(flow, totalChargePower, time.Now())
 
 		// add battery charging power to homePower to ignore all consumption which does not occur on loadpoints
 		// fix for: https://github.com/evcc-io/evcc/issues/11032
@@ -796,6 +893,7 @@ func (site *Site) update(lp updater) {
 		}
 	} else {
 		site.log.ERROR.Println(err)
+		lp.meterOfflineFallback(site.sitePowerOffline(time.Now()))
 	}
 
 	if site.batteryDischargeControl {
@@ -834,6 +932,13 @@ func (site *Site) prepare() {
 	vehicle.Publish = site.publishVehicles
 }
 
+// SetMetricsRegisterer registers loadpoint metrics with the given registerer. Must be called before Prepare.
+func (site *Site) SetMetricsRegisterer(reg prometheus.Registerer) {
+	for _, lp := range site.loadpoints {
+		lp.metricsRegisterer = reg
+	}
+}
+
 // Prepare attaches communication channels to site and loadpoints
 func (site *Site) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Event) {
 	// https://github.com/evcc-io/evcc/issues/11191 prevent deadlock
@@ -856,6 +961,10 @@ func (site *Site) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Event)
 
 	site.prepare()
 
+	// stop loadpoint background tasks on shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	shutdown.Register(cancel)
+
 	for id, lp := range site.loadpoints {
 		lpUIChan := make(chan util.Param)
 		lpPushChan := make(chan push.Event)
@@ -874,7 +983,7 @@ func (site *Site) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Event)
 			}
 		}(id)
 
-		lp.Prepare(lpUIChan, lpPushChan, site.lpUpdateChan)
+		lp.Prepare(ctx, lpUIChan, lpPushChan, site.lpUpdateChan)
 	}
 }
 
@@ -20,6 +20,8 @@ const (
 	GridTariff    = "grid"
 	FeedinTariff  = "feedin"
 	PlannerTariff = "planner"
+	SolarTariff   = "solar"
+	Co2Tariff     = "co2"
 )
 
 // isConfigurable checks if the meter is configurable
@@ -285,6 +287,12 @@ func (site *Site) GetTariff(tariff string) api.Tariff {
 			return site.tariffs.Grid
 		}
 
+	case SolarTariff:
+		return site.tariffs.Solar
+
+	case Co2Tariff:
+		return site.tariffs.Co2
+
 	default:
 		return nil
 	}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"time"
+)
+
+// pvEntitlement distributes the pv surplus between loadpoints by weight
+func pvEntitlement(surplus float64, weights []float64) []float64 {
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+
+	res := make([]float64, len(weights))
+	if total == 0 {
+		return res
+	}
+
+	for i, w := range weights {
+		res[i] = w * surplus / total
+	}
+
+	return res
+}
+
+// updatePvFairness accumulates received and priority-weighted entitled pv energy per loadpoint
+func (site *Site) updatePvFairness(flow EnergyFlow, totalChargePower float64, now time.Time) {
+	updated := site.fairnessUpdated
+	site.fairnessUpdated = now
+
+	if updated.IsZero() {
+		return
+	}
+
+	// energy in Wh since last update
+	hours := now.Sub(updated).Hours()
+	surplus := (flow.PvEV + flow.PvBattery + flow.PvGrid) * hours
+
+	weights := make([]float64, len(site.loadpoints))
+	for i, lp := range site.loadpoints {
+		// only connected vehicles compete for surplus
+		if lp.connected() {
+			weights[i] = float64(1 + lp.EffectivePriority())
+		}
+	}
+
+	for i, entitled := range pvEntitlement(surplus, weights) {
+		lp := site.loadpoints[i]
+
+		var received float64
+		if totalChargePower > 0 {
+			received = flow.PvEV * hours * lp.GetChargePower() / totalChargePower
+		}
+
+		lp.addPvFairness(received, entitled, now)
+	}
+}
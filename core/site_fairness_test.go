@@ -0,0 +1,56 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPvEntitlement(t *testing.T) {
+	assert.Equal(t, []float64{0, 0}, pvEntitlement(1000, []float64{0, 0}))
+	assert.Equal(t, []float64{500, 500}, pvEntitlement(1000, []float64{1, 1}))
+	assert.Equal(t, []float64{750, 0, 250}, pvEntitlement(1000, []float64{3, 0, 1}))
+}
+
+func TestPvFairness(t *testing.T) {
+	lp1 := &Loadpoint{log: util.NewLogger("lp1"), status: api.StatusC, chargePower: 3000}
+	lp2 := &Loadpoint{log: util.NewLogger("lp2"), status: api.StatusC, chargePower: 3000}
+
+	site := &Site{
+		log:        util.NewLogger("foo"),
+		loadpoints: []*Loadpoint{lp1, lp2},
+	}
+
+	now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+	flow := energyFlow(8000, 0, 0, 6000)
+
+	// two loadpoints sharing surplus equally
+	for range 4 {
+		site.updatePvFairness(flow, 6000, now)
+		now = now.Add(15 * time.Minute)
+	}
+
+	assert.InDelta(t, 2250, lp1.pvEnergyReceived, 1e-6)
+	assert.InDelta(t, 2250, lp1.pvEnergyEntitled, 1e-6)
+	assert.Equal(t, lp1.pvEnergyReceived, lp2.pvEnergyReceived)
+	assert.Equal(t, lp1.pvEnergyEntitled, lp2.pvEnergyEntitled)
+
+	// disconnected loadpoint is not entitled
+	lp2.status = api.StatusA
+	lp2.chargePower = 0
+	flow = energyFlow(8000, 0, 0, 3000)
+	site.updatePvFairness(flow, 3000, now)
+
+	assert.InDelta(t, 3000, lp1.pvEnergyEntitled, 1e-6)
+	assert.InDelta(t, 2250, lp2.pvEnergyEntitled, 1e-6)
+
+	// new month resets counters
+	now = now.Add(12 * time.Hour)
+	site.updatePvFairness(flow, 3000, now)
+
+	assert.Equal(t, time.April, lp1.pvFairnessMonth.Month())
+	assert.InDelta(t, 0, lp2.pvEnergyEntitled, 1e-6)
+}
@@ -0,0 +1,47 @@
+package core
+
+// EnergyFlow is a snapshot of the power flows between sources and consumers in W
+type EnergyFlow struct {
+	PvHome      float64 `json:"pvHome"`
+	PvEV        float64 `json:"pvEV"`
+	PvBattery   float64 `json:"pvBattery"`
+	PvGrid      float64 `json:"pvGrid"`
+	GridHome    float64 `json:"gridHome"`
+	GridEV      float64 `json:"gridEV"`
+	GridBattery float64 `json:"gridBattery"`
+	BatteryHome float64 `json:"batteryHome"`
+	BatteryEV   float64 `json:"batteryEV"`
+}
+
+// energyFlow distributes pv, battery and grid power to home, vehicles, battery and grid.
+// Pv is consumed first, then battery discharge, then grid import. Home is served before vehicles.
+func energyFlow(pvPower, gridPower, batteryPower, chargePower float64) EnergyFlow {
+	pv := max(0, pvPower)
+	discharge := max(0, batteryPower)
+	charge := max(0, -batteryPower)
+	ev := max(0, chargePower)
+	home := max(0, gridPower+pv+batteryPower-ev)
+
+	var res EnergyFlow
+
+	// take up to demand from supply and return the taken power
+	take := func(supply *float64, demand float64) float64 {
+		res := min(*supply, max(0, demand))
+		*supply -= res
+		return res
+	}
+
+	res.PvHome = take(&pv, home)
+	res.PvEV = take(&pv, ev)
+	res.PvBattery = take(&pv, charge)
+	res.PvGrid = pv
+
+	res.BatteryHome = take(&discharge, home-res.PvHome)
+	res.BatteryEV = take(&discharge, ev-res.PvEV)
+
+	res.GridHome = max(0, home-res.PvHome-res.BatteryHome)
+	res.GridEV = max(0, ev-res.PvEV-res.BatteryEV)
+	res.GridBattery = max(0, charge-res.PvBattery)
+
+	return res
+}
@@ -0,0 +1,38 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnergyFlow(t *testing.T) {
+	tc := []struct {
+		desc                     string
+		pv, grid, battery, evPwr float64
+		res                      EnergyFlow
+	}{
+		{"grid only", 0, 3000, 0, 2000, EnergyFlow{GridHome: 1000, GridEV: 2000}},
+		{"pv export", 5000, -3000, 0, 0, EnergyFlow{PvHome: 2000, PvGrid: 3000}},
+		{"pv to home and ev", 5000, 0, 0, 4000, EnergyFlow{PvHome: 1000, PvEV: 4000}},
+		{"pv to battery", 5000, -1000, -2000, 1500, EnergyFlow{PvHome: 500, PvEV: 1500, PvBattery: 2000, PvGrid: 1000}},
+		{"battery discharge", 1000, 500, 3000, 4000, EnergyFlow{PvHome: 500, PvEV: 500, BatteryEV: 3000, GridEV: 500}},
+		{"battery to home", 0, 0, 800, 0, EnergyFlow{BatteryHome: 800}},
+		{"grid charges battery", 0, 2500, -2000, 0, EnergyFlow{GridHome: 500, GridBattery: 2000}},
+		{"negative pv ignored", -20, 1000, 0, 0, EnergyFlow{GridHome: 1000}},
+	}
+
+	for _, tc := range tc {
+		t.Log(tc.desc)
+
+		res := energyFlow(tc.pv, tc.grid, tc.battery, tc.evPwr)
+		assert.Equal(t, tc.res, res, tc.desc)
+
+		for _, f := range []float64{res.PvHome, res.PvEV, res.PvBattery, res.PvGrid, res.GridHome, res.GridEV, res.GridBattery, res.BatteryHome, res.BatteryEV} {
+			assert.GreaterOrEqual(t, f, 0.0, tc.desc)
+		}
+
+		assert.Equal(t, max(0, tc.pv), res.PvHome+res.PvEV+res.PvBattery+res.PvGrid, tc.desc)
+		assert.Equal(t, tc.evPwr, res.PvEV+res.GridEV+res.BatteryEV, tc.desc)
+	}
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"math"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+const (
+	forecastAdjustmentHour = 6    // hour of day when enable thresholds are adjusted to the pv forecast
+	forecastReferenceDecay = 0.98 // daily decay of the best forecast day to follow seasonal changes
+)
+
+// beginningOfDay returns midnight of the given time's day
+func beginningOfDay(ts time.Time) time.Time {
+	return time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, ts.Location())
+}
+
+// forecastDailyEnergy returns the forecast energy in Wh by day. Rate prices are expected to be generation in W.
+func forecastDailyEnergy(rates api.Rates, loc *time.Location) map[time.Time]float64 {
+	res := make(map[time.Time]float64)
+
+	for _, r := range rates {
+		day := beginningOfDay(r.Start.In(loc))
+		res[day] += r.Price * r.End.Sub(r.Start).Hours()
+	}
+
+	return res
+}
+
+// forecastConfidence returns the ratio of the day's forecast energy to the best forecast day seen
+func (site *Site) forecastConfidence(rates api.Rates, day time.Time) (float64, bool) {
+	energy := forecastDailyEnergy(rates, day.Location())
+
+	if site.forecastReferenceDay.IsZero() || day.After(site.forecastReferenceDay) {
+		if !site.forecastReferenceDay.IsZero() {
+			days := math.Round(day.Sub(site.forecastReferenceDay).Hours() / 24)
+			site.forecastReference *= math.Pow(forecastReferenceDecay, days)
+		}
+		site.forecastReferenceDay = day
+	}
+
+	for _, e := range energy {
+		site.forecastReference = max(site.forecastReference, e)
+	}
+
+	today, ok := energy[day]
+	if !ok || site.forecastReference <= 0 {
+		return 0, false
+	}
+
+	return min(today/site.forecastReference, 1), true
+}
+
+// adjustEnableThresholds adjusts all loadpoints' pv enable thresholds once per day according to the pv forecast confidence
+func (site *Site) adjustEnableThresholds(now time.Time) {
+	if site.BaseEnableThreshold == 0 || site.MaxForecastBias == 0 {
+		return
+	}
+
+	day := beginningOfDay(now)
+	if now.Hour() < forecastAdjustmentHour || !site.forecastAdjusted.Before(day) {
+		return
+	}
+
+	tariff := site.GetTariff(SolarTariff)
+	if tariff == nil {
+		return
+	}
+
+	rates, err := tariff.Rates()
+	if err != nil {
+		site.log.ERROR.Println("pv forecast:", err)
+		return
+	}
+
+	confidence, ok := site.forecastConfidence(rates, day)
+	if !ok {
+		site.log.DEBUG.Println("pv forecast: no forecast for today")
+		return
+	}
+
+	site.forecastAdjusted = day
+
+	threshold := site.BaseEnableThreshold * (1 + (1-confidence)*site.MaxForecastBias)
+	site.log.DEBUG.Printf("pv forecast confidence: %.2f, enable threshold: %.0fW", confidence, threshold)
+	site.publish(keys.ForecastConfidence, confidence)
+
+	for _, lp := range site.loadpoints {
+		lp.SetEnableThreshold(threshold)
+	}
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// forecast creates hourly pv forecast rates with constant power between 08:00 and 16:00 for each day
+func forecast(start time.Time, power ...float64) api.Rates {
+	var res api.Rates
+	for d, p := range power {
+		for h := 8; h < 16; h++ {
+			ts := start.AddDate(0, 0, d).Add(time.Duration(h) * time.Hour)
+			res = append(res, api.Rate{Start: ts, End: ts.Add(time.Hour), Price: p})
+		}
+	}
+	return res
+}
+
+func TestForecastEnableThreshold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	solar := api.NewMockTariff(ctrl)
+
+	lp := &Loadpoint{log: util.NewLogger("foo")}
+
+	site := &Site{
+		log:                 util.NewLogger("foo"),
+		loadpoints:          []*Loadpoint{lp},
+		tariffs:             &tariff.Tariffs{Solar: solar},
+		BaseEnableThreshold: -1000,
+		MaxForecastBias:     1,
+	}
+
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// before 06:00
+	site.adjustEnableThresholds(day.Add(5 * time.Hour))
+	assert.Equal(t, 0.0, lp.GetEnableThreshold())
+
+	// overcast today, clear sky tomorrow
+	solar.EXPECT().Rates().Return(forecast(day, 1000, 4000), nil)
+	site.adjustEnableThresholds(day.Add(6 * time.Hour))
+	assert.Equal(t, -1750.0, lp.GetEnableThreshold())
+
+	// once per day only
+	site.adjustEnableThresholds(day.Add(12 * time.Hour))
+	assert.Equal(t, -1750.0, lp.GetEnableThreshold())
+
+	// clear sky
+	day = day.AddDate(0, 0, 1)
+	solar.EXPECT().Rates().Return(forecast(day, 4000, 2000), nil)
+	site.adjustEnableThresholds(day.Add(6 * time.Hour))
+	assert.Equal(t, -1000.0, lp.GetEnableThreshold())
+
+	// half of best day seen, reference decayed by one day
+	day = day.AddDate(0, 0, 1)
+	solar.EXPECT().Rates().Return(forecast(day, 2000), nil)
+	site.adjustEnableThresholds(day.Add(7 * time.Hour))
+	assert.InDelta(t, -1000*(2-16000/(32000*forecastReferenceDecay)), lp.GetEnableThreshold(), 1e-6)
+
+	// reference follows seasonal decline
+	day = day.AddDate(0, 0, 60)
+	solar.EXPECT().Rates().Return(forecast(day, 2000), nil)
+	site.adjustEnableThresholds(day.Add(7 * time.Hour))
+	assert.Equal(t, -1000.0, lp.GetEnableThreshold())
+}
@@ -0,0 +1,106 @@
+package core
+
+// fuseDemandTolerance is the margin below the allocation within which a charge current is considered capped by the fuse,
+// since chargers may only support full amps
+const fuseDemandTolerance = 1.0 // A
+
+// fuseDemand is a loadpoint's current demand on the shared fuse
+type fuseDemand struct {
+	current    float64 // A per phase
+	maxCurrent float64 // A per phase the loadpoint could draw
+	phases     int
+}
+
+// fuseAllocation distributes the available fuse current per phase proportionally between loadpoint demands.
+// Remaining headroom is shared proportionally up to each loadpoint's max current.
+// Single and two phase loadpoints are assumed to be connected starting at L1.
+func fuseAllocation(available float64, demands []fuseDemand) []float64 {
+	var phaseCurrents, phaseHeadroom [3]float64
+	for _, d := range demands {
+		for i := range min(d.phases, 3) {
+			phaseCurrents[i] += d.current
+			phaseHeadroom[i] += max(d.maxCurrent-d.current, 0)
+		}
+	}
+
+	factor := 1.0
+	if total := max(phaseCurrents[0], phaseCurrents[1], phaseCurrents[2]); total > available {
+		factor = available / total
+	}
+
+	// share of the headroom granted on top of the demand
+	headroom := 0.0
+	if factor == 1 {
+		headroom = 1.0
+		for i := range phaseHeadroom {
+			if phaseHeadroom[i] > 0 {
+				headroom = min(headroom, (available-phaseCurrents[i])/phaseHeadroom[i])
+			}
+		}
+	}
+
+	res := make([]float64, len(demands))
+	for i, d := range demands {
+		res[i] = d.current*factor + max(d.maxCurrent-d.current, 0)*headroom
+	}
+
+	return res
+}
+
+// allocateFuseCurrent limits loadpoint max currents such that the shared fuse is not exceeded
+func (site *Site) allocateFuseCurrent() {
+	if site.FuseRating <= 0 {
+		return
+	}
+
+	available := site.FuseRating - site.GridCurrentReserve
+	if available <= 0 {
+		site.log.ERROR.Printf("fuse rating %.0fA must exceed grid current reserve %.0fA", site.FuseRating, site.GridCurrentReserve)
+		return
+	}
+
+	demands := make([]fuseDemand, len(site.loadpoints))
+	for i, lp := range site.loadpoints {
+		// connected state is only refreshed by the loadpoint's own update,
+		// reserve min current for vehicles that may connect and start charging any time
+		minCurrent := lp.effectiveMinCurrent()
+
+		if lp.connected() {
+			maxCurrent := lp.unallocatedMaxCurrent()
+			demands[i] = fuseDemand{
+				current:    min(max(lp.fuseDemandCurrent(), minCurrent), maxCurrent),
+				maxCurrent: maxCurrent,
+				phases:     lp.ActivePhases(),
+			}
+		} else {
+			demands[i] = fuseDemand{current: minCurrent, maxCurrent: minCurrent, phases: lp.maxActivePhases()}
+		}
+	}
+
+	for i, current := range fuseAllocation(available, demands) {
+		lp := site.loadpoints[i]
+
+		if current < demands[i].maxCurrent {
+			lp.log.DEBUG.Printf("fuse allocation: %.3gA", current)
+		}
+
+		lp.setAllocatedCurrent(current, true)
+	}
+}
+
+// fuseDemandCurrent returns the current the loadpoint targets on the shared fuse.
+// Disabled loadpoints demand min current since they may start charging any time,
+// loadpoints capped by their allocation demand their unallocated max current.
+func (lp *Loadpoint) fuseDemandCurrent() float64 {
+	maxCurrent := lp.unallocatedMaxCurrent()
+
+	if !lp.enabled {
+		return min(lp.effectiveMinCurrent(), maxCurrent)
+	}
+
+	if allocated, ok := lp.getAllocatedCurrent(); ok && lp.chargeCurrent >= allocated-fuseDemandTolerance {
+		return maxCurrent
+	}
+
+	return min(lp.chargeCurrent, maxCurrent)
+}
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuseAllocation(t *testing.T) {
+	tc := []struct {
+		available float64
+		demands   []fuseDemand
+		expected  []float64
+	}{
+		{40, []fuseDemand{{16, 16, 3}, {16, 16, 3}}, []float64{16, 16}},
+		{40, []fuseDemand{{16, 16, 3}, {16, 16, 3}, {16, 16, 3}}, []float64{40.0 / 3, 40.0 / 3, 40.0 / 3}},
+		{40, []fuseDemand{{16, 16, 1}, {16, 16, 1}, {16, 16, 3}}, []float64{16 * 40.0 / 48, 16 * 40.0 / 48, 16 * 40.0 / 48}},
+		{40, []fuseDemand{{16, 16, 3}, {0, 0, 0}, {16, 16, 1}}, []float64{16, 0, 16}},
+		{30, []fuseDemand{{32, 32, 3}, {16, 16, 3}}, []float64{20, 10}},
+		{40, []fuseDemand{{16, 16, 3}, {6, 16, 3}}, []float64{16, 16}},
+		{40, []fuseDemand{{16, 16, 3}, {16, 16, 3}, {6, 16, 3}}, []float64{16, 16, 8}},
+		{40, []fuseDemand{{16, 32, 3}, {16, 16, 3}, {6, 6, 1}}, []float64{18, 16, 6}},
+	}
+
+	for _, tc := range tc {
+		t.Logf("%+v", tc)
+
+		res := fuseAllocation(tc.available, tc.demands)
+		assert.InDeltaSlice(t, tc.expected, res, 1e-9)
+	}
+}
+
+func TestSiteFuseAllocation(t *testing.T) {
+	var lps []*Loadpoint
+	for range 3 {
+		lps = append(lps, &Loadpoint{
+			log:           util.NewLogger("foo"),
+			status:        api.StatusC,
+			enabled:       true,
+			chargeCurrent: 16,
+			phases:        3,
+			minCurrent:    6,
+			maxCurrent:    16,
+		})
+	}
+
+	site := &Site{
+		log:        util.NewLogger("foo"),
+		loadpoints: lps,
+		FuseRating: 40,
+	}
+
+	site.allocateFuseCurrent()
+
+	for _, lp := range lps {
+		assert.InDelta(t, 40.0/3, lp.effectiveMaxCurrent(), 1e-9)
+	}
+
+	// reserve for household consumption
+	site.GridCurrentReserve = 10
+	site.allocateFuseCurrent()
+
+	for _, lp := range lps {
+		assert.InDelta(t, 10.0, lp.effectiveMaxCurrent(), 1e-9)
+	}
+
+	// disconnected loadpoint releases its share except for min current
+	lps[2].status = api.StatusA
+	site.allocateFuseCurrent()
+
+	assert.InDelta(t, 16*30.0/38, lps[0].effectiveMaxCurrent(), 1e-9)
+	assert.InDelta(t, 16*30.0/38, lps[1].effectiveMaxCurrent(), 1e-9)
+	assert.InDelta(t, 6*30.0/38, lps[2].effectiveMaxCurrent(), 1e-9)
+
+	// loadpoint charging below its allocation releases the remainder
+	lps[2].status = api.StatusC
+	site.GridCurrentReserve = 0
+	site.allocateFuseCurrent()
+	lps[2].chargeCurrent = 6
+
+	for range 2 {
+		site.allocateFuseCurrent()
+
+		assert.InDelta(t, 16.0, lps[0].effectiveMaxCurrent(), 1e-9)
+		assert.InDelta(t, 16.0, lps[1].effectiveMaxCurrent(), 1e-9)
+		assert.InDelta(t, 8.0, lps[2].effectiveMaxCurrent(), 1e-9)
+	}
+
+	// disabled loadpoint demands min current
+	lps[2].enabled = false
+	lps[2].chargeCurrent = 0
+	site.FuseRating = 30
+	site.allocateFuseCurrent()
+
+	assert.InDelta(t, 16*30.0/38, lps[0].effectiveMaxCurrent(), 1e-9)
+	assert.InDelta(t, 6*30.0/38, lps[2].effectiveMaxCurrent(), 1e-9)
+}
+
+func TestSiteFuseAllocationConnect(t *testing.T) {
+	charging := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		status:        api.StatusC,
+		enabled:       true,
+		chargeCurrent: 16,
+		phases:        3,
+		minCurrent:    6,
+		maxCurrent:    16,
+	}
+
+	idle := &Loadpoint{
+		log:        util.NewLogger("foo"),
+		status:     api.StatusA,
+		phases:     3,
+		minCurrent: 6,
+		maxCurrent: 16,
+	}
+
+	site := &Site{
+		log:        util.NewLogger("foo"),
+		loadpoints: []*Loadpoint{charging, idle},
+		FuseRating: 25,
+	}
+
+	site.allocateFuseCurrent()
+
+	// min current is reserved for the disconnected loadpoint
+	assert.InDelta(t, 16.0, charging.effectiveMaxCurrent(), 1e-9)
+	assert.InDelta(t, 6.0, idle.effectiveMaxCurrent(), 1e-9)
+
+	// vehicle connects and starts charging from the reserved share before the next allocation
+	idle.status = api.StatusC
+	idle.enabled = true
+	idle.chargeCurrent = idle.effectiveMaxCurrent()
+
+	assert.LessOrEqual(t, charging.effectiveMaxCurrent()+idle.effectiveMaxCurrent(), site.FuseRating)
+
+	// both loadpoints capped by their allocation share the fuse
+	site.allocateFuseCurrent()
+
+	assert.InDelta(t, 12.5, charging.effectiveMaxCurrent(), 1e-9)
+	assert.InDelta(t, 12.5, idle.effectiveMaxCurrent(), 1e-9)
+}
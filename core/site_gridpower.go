@@ -0,0 +1,69 @@
+package core
+
+import (
+	"cmp"
+	"slices"
+)
+
+// powerDemand is a loadpoint's power demand on the grid connection
+type powerDemand struct {
+	power    float64 // W
+	priority int
+}
+
+// gridPowerAllocation distributes the available grid power between loadpoint demands.
+// Higher priority demands are served first, equal priorities in loadpoint order.
+func gridPowerAllocation(available float64, demands []powerDemand) []float64 {
+	idx := make([]int, len(demands))
+	for i := range idx {
+		idx[i] = i
+	}
+
+	slices.SortStableFunc(idx, func(a, b int) int {
+		return cmp.Compare(demands[b].priority, demands[a].priority)
+	})
+
+	res := make([]float64, len(demands))
+	for _, i := range idx {
+		res[i] = min(demands[i].power, max(available, 0))
+		available -= res[i]
+	}
+
+	return res
+}
+
+// allocateGridPower limits loadpoint max currents such that grid import does not exceed the max grid power
+func (site *Site) allocateGridPower(gridPower, totalChargePower float64) {
+	if site.MaxGridPower <= 0 {
+		return
+	}
+
+	// grid power available for charging, including current charge power
+	available := site.MaxGridPower - (gridPower - totalChargePower)
+
+	demands := make([]powerDemand, len(site.loadpoints))
+	for i, lp := range site.loadpoints {
+		// connected vehicles may start charging any time
+		if lp.connected() {
+			demands[i] = powerDemand{
+				power:    lp.GetMaxCurrent() * float64(lp.ActivePhases()) * lp.effectiveVoltage(),
+				priority: lp.GetPriority(),
+			}
+		}
+	}
+
+	for i, power := range gridPowerAllocation(available, demands) {
+		lp := site.loadpoints[i]
+
+		if demands[i].power == 0 {
+			lp.setAllocatedPower(0, false)
+			continue
+		}
+
+		if power < demands[i].power {
+			lp.log.DEBUG.Printf("grid power allocation: %.0fW", power)
+		}
+
+		lp.setAllocatedPower(power, true)
+	}
+}
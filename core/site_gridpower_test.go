@@ -0,0 +1,79 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGridPowerAllocation(t *testing.T) {
+	tc := []struct {
+		available float64
+		demands   []powerDemand
+		expected  []float64
+	}{
+		{20000, []powerDemand{{11000, 0}, {7000, 0}}, []float64{11000, 7000}},
+		{15000, []powerDemand{{11000, 0}, {11000, 0}}, []float64{11000, 4000}},
+		{15000, []powerDemand{{11000, 0}, {11000, 1}}, []float64{4000, 11000}},
+		{15000, []powerDemand{{0, 2}, {11000, 1}, {11000, 0}}, []float64{0, 11000, 4000}},
+		{-1000, []powerDemand{{11000, 0}}, []float64{0}},
+	}
+
+	for _, tc := range tc {
+		t.Logf("%+v", tc)
+
+		res := gridPowerAllocation(tc.available, tc.demands)
+		assert.Equal(t, tc.expected, res)
+	}
+}
+
+func TestSiteGridPowerAllocation(t *testing.T) {
+	Voltage = 230 // V
+
+	var lps []*Loadpoint
+	for i := range 2 {
+		lps = append(lps, &Loadpoint{
+			log:        util.NewLogger("foo"),
+			status:     api.StatusC,
+			phases:     3,
+			minCurrent: 6,
+			maxCurrent: 16,
+			Priority_:  i,
+		})
+	}
+
+	site := &Site{
+		log:        util.NewLogger("foo"),
+		loadpoints: lps,
+	}
+
+	// unlimited
+	site.allocateGridPower(3000, 0)
+	for _, lp := range lps {
+		assert.Equal(t, 16.0, lp.effectiveMaxCurrent())
+	}
+
+	// 3kW household consumption, higher priority loadpoint charges first
+	site.MaxGridPower = 3000 + 3*230*16 + 3*230*10
+	site.allocateGridPower(3000, 0)
+
+	assert.InDelta(t, 10.0, lps[0].effectiveMaxCurrent(), 1e-9)
+	assert.Equal(t, 16.0, lps[1].effectiveMaxCurrent())
+
+	// measured grid power includes current charge power
+	site.allocateGridPower(3000+5000, 5000)
+
+	assert.InDelta(t, 10.0, lps[0].effectiveMaxCurrent(), 1e-9)
+	assert.Equal(t, 16.0, lps[1].effectiveMaxCurrent())
+
+	// disconnected loadpoints are not limited
+	lps[1].status = api.StatusA
+	site.allocateGridPower(3000, 0)
+
+	assert.Equal(t, 16.0, lps[0].effectiveMaxCurrent())
+	_, ok := lps[1].getAllocatedPower()
+	require.False(t, ok)
+}
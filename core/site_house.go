@@ -0,0 +1,30 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// site power interpretations
+const (
+	sitePowerGrid        = "grid"          // grid power including battery
+	sitePowerPvMinusLoad = "pv_minus_load" // pv production minus house and charge consumption
+)
+
+// pvMinusLoadPower returns the site power from pv production and consumption.
+// Battery charging is considered available unless reserved for battery priority.
+func pvMinusLoadPower(pvPower, housePower, totalChargePower, batteryReserved, residualPower float64) float64 {
+	return housePower + totalChargePower - pvPower - batteryReserved + residualPower
+}
+
+// updateHouseMeter reads and publishes the house consumption
+func (site *Site) updateHouseMeter() (float64, error) {
+	power, err := site.houseMeter.CurrentPower()
+	if err != nil {
+		return 0, err
+	}
+
+	site.log.DEBUG.Printf("house power: %.0fW", power)
+	site.publish(keys.HousePower, power)
+
+	return power, nil
+}
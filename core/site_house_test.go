@@ -0,0 +1,67 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSitePowerInterpretation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	Voltage = 230 // V
+
+	// 5kW pv, 1kW house, 2.3kW charging, 1.5kW unmetered battery charging
+	const pvPower, housePower, chargePower = 5000.0, 1000.0, 2300.0
+	gridPower := housePower + chargePower - pvPower + 1500
+
+	for _, tc := range []struct {
+		interpretation string
+		site           float64
+		current        float64
+	}{
+		{sitePowerGrid, -200, 10.869565},
+		{sitePowerPvMinusLoad, -1700, 17.391304},
+	} {
+		t.Log(tc.interpretation)
+
+		grid := api.NewMockMeter(ctrl)
+		grid.EXPECT().CurrentPower().Return(gridPower, nil)
+
+		pv := api.NewMockMeter(ctrl)
+		pv.EXPECT().CurrentPower().Return(pvPower, nil)
+
+		house := api.NewMockMeter(ctrl)
+		house.EXPECT().CurrentPower().Return(housePower, nil).AnyTimes()
+
+		site := &Site{
+			log:                     util.NewLogger("foo"),
+			gridMeter:               grid,
+			pvMeters:                []api.Meter{pv},
+			houseMeter:              house,
+			SitePowerInterpretation: tc.interpretation,
+		}
+
+		sitePower, _, _, err := site.sitePower(chargePower, 0)
+		require.NoError(t, err)
+		assert.InDelta(t, tc.site, sitePower, 1e-6)
+
+		lp := &Loadpoint{
+			log:           util.NewLogger("foo"),
+			clock:         clock.NewMock(),
+			status:        api.StatusC,
+			enabled:       true,
+			phases:        1,
+			minCurrent:    6,
+			maxCurrent:    32,
+			chargeCurrent: 10,
+		}
+
+		assert.InDelta(t, tc.current, lp.pvMaxCurrent(api.ModePV, sitePower, false, false), 1e-6)
+	}
+}
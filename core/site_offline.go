@@ -0,0 +1,12 @@
+package core
+
+import "time"
+
+// sitePowerOffline returns the duration of the current site meter outage
+func (site *Site) sitePowerOffline(now time.Time) time.Duration {
+	if site.sitePowerFailed.IsZero() {
+		site.sitePowerFailed = now
+	}
+
+	return now.Sub(site.sitePowerFailed)
+}
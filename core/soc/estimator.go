@@ -102,6 +102,16 @@ func (s *Estimator) RemainingChargeEnergy(targetSoc int) float64 {
 	return whRemaining / 1e3
 }
 
+// Estimate extrapolates the last fetched soc by the energy charged since, without fetching the vehicle soc
+func (s *Estimator) Estimate(chargedEnergy float64) float64 {
+	if s.estimate && s.prevSoc > 0 && s.energyPerSocStep > 0 {
+		energyDelta := max(chargedEnergy-s.prevChargedEnergy, 0)
+		s.vehicleSoc = min(s.prevSoc+energyDelta/s.energyPerSocStep, 100)
+	}
+
+	return s.vehicleSoc
+}
+
 // Soc replaces the api.Vehicle.Soc interface to take charged energy into account
 func (s *Estimator) Soc(chargedEnergy float64) (float64, error) {
 	var fetchedSoc *float64
@@ -242,3 +242,34 @@ func TestImprovedEstimatorRemainingChargeDuration(t *testing.T) {
 		assert.Equal(t, tc.duration, ce.RemainingChargeDuration(tc.targetsoc, tc.chargePower))
 	}
 }
+
+func TestSocEstimate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+	vehicle := api.NewMockVehicle(ctrl)
+
+	// 9 kWh user battery capacity is converted to initial value of 10 kWh virtual capacity
+	vehicle.EXPECT().Capacity().Return(float64(9)).AnyTimes()
+
+	ce := NewEstimator(util.NewLogger("foo"), charger, vehicle, true)
+
+	// no fetched soc yet
+	assert.Equal(t, 0.0, ce.Estimate(1000))
+
+	vehicle.EXPECT().Soc().Return(20.0, nil)
+	_, err := ce.Soc(0)
+	assert.NoError(t, err)
+
+	// extrapolated from fetched soc without polling the vehicle
+	assert.InDelta(t, 30.0, ce.Estimate(1000), 1e-9)
+	assert.Equal(t, 100.0, ce.Estimate(20000))
+
+	// estimation disabled
+	ce = NewEstimator(util.NewLogger("foo"), charger, vehicle, false)
+
+	vehicle.EXPECT().Soc().Return(20.0, nil)
+	_, err = ce.Soc(0)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 20.0, ce.Estimate(1000))
+}
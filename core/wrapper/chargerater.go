@@ -7,9 +7,20 @@ import (
 
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/util"
 )
 
+// chargeRaterState is the persisted state of a charge rater
+type chargeRaterState struct {
+	Connected     bool      `json:"connected"`
+	Vehicle       string    `json:"vehicle"`
+	Charging      bool      `json:"charging"`
+	Start         time.Time `json:"start"`
+	StartEnergy   float64   `json:"startEnergy"`
+	ChargedEnergy float64   `json:"chargedEnergy"`
+}
+
 // ChargeRater is responsible for providing charged energy amount
 // by implementing api.ChargeRater. It uses the charge meter's TotalEnergy or
 // keeps track of consumed energy by regularly updating consumed power.
@@ -22,23 +33,94 @@ type ChargeRater struct {
 	start         time.Time
 	startEnergy   float64
 	chargedEnergy float64
+	connected     bool
+	vehicle       string
+	store         StateStore
+	restored      bool // restored session not yet continued
+	unverified    bool // restored session not yet matched to the identified vehicle
 }
 
-// NewChargeRater creates charge rater and initializes realtime clock
-func NewChargeRater(log *util.Logger, meter api.Meter) *ChargeRater {
-	return &ChargeRater{
+// NewChargeRater creates charge rater and initializes realtime clock.
+// If store is not nil, state is restored from and persisted to the store.
+func NewChargeRater(log *util.Logger, meter api.Meter, store StateStore) *ChargeRater {
+	cr := &ChargeRater{
 		log:   log,
 		clck:  clock.New(),
 		meter: meter,
+		store: store,
+	}
+
+	if store != nil {
+		var res chargeRaterState
+		if err := store.Load(keys.ChargeRaterState, &res); err == nil && res.Connected {
+			cr.connected = true
+			cr.vehicle = res.Vehicle
+			cr.charging = res.Charging
+			cr.start = res.Start
+			cr.startEnergy = res.StartEnergy
+			cr.chargedEnergy = res.ChargedEnergy
+			cr.restored = true
+			cr.unverified = true
+			cr.log.DEBUG.Printf("restored charge energy: %.3gkWh", cr.chargedEnergy)
+		}
+	}
+
+	return cr
+}
+
+// save persists the charge rater state
+func (cr *ChargeRater) save() {
+	if cr.store == nil {
+		return
+	}
+
+	if err := cr.store.Save(keys.ChargeRaterState, chargeRaterState{
+		Connected:     cr.connected,
+		Vehicle:       cr.vehicle,
+		Charging:      cr.charging,
+		Start:         cr.start,
+		StartEnergy:   cr.startEnergy,
+		ChargedEnergy: cr.chargedEnergy,
+	}); err != nil {
+		cr.log.ERROR.Printf("charge rater state: %v", err)
 	}
 }
 
 // StartCharge records meter start energy. If meter does not supply TotalEnergy,
 // start time is recorded and  charged energy set to zero.
+// A session restored from the store is continued on the first connect.
 func (cr *ChargeRater) StartCharge(continued bool) {
 	cr.Lock()
 	defer cr.Unlock()
+	defer cr.save()
+
+	cr.connected = true
+
+	if cr.restored {
+		cr.restored = false
+		if !continued {
+			return
+		}
+	}
 
+	// restored while charging
+	if continued && cr.charging {
+		return
+	}
+
+	cr.startMeasurement()
+
+	if continued {
+		cr.charging = true
+	} else {
+		cr.chargedEnergy = 0
+		cr.vehicle = ""
+		cr.unverified = false
+	}
+}
+
+// startMeasurement records start time and meter start energy (no mutex)
+func (cr *ChargeRater) startMeasurement() {
 	// time is needed if MeterEnergy is not supported
 	cr.start = cr.clck.Now()
 
@@ -51,12 +133,25 @@ func (cr *ChargeRater) StartCharge(continued bool) {
 			cr.log.ERROR.Printf("charge meter: %v", err)
 		}
 	}
+}
+
+// SetVehicle signals the identified vehicle. A restored session of a different vehicle is discarded.
+func (cr *ChargeRater) SetVehicle(vehicle string) {
+	cr.Lock()
+	defer cr.Unlock()
+	defer cr.save()
+
+	if cr.unverified && cr.vehicle != "" && cr.vehicle != vehicle {
+		cr.log.DEBUG.Printf("discarding restored charge energy of %s", cr.vehicle)
 
-	if continued {
-		cr.charging = true
-	} else {
 		cr.chargedEnergy = 0
+		if cr.charging {
+			cr.startMeasurement()
+		}
 	}
+
+	cr.unverified = false
+	cr.vehicle = vehicle
 }
 
 // StopCharge records meter stop energy. If meter does not supply TotalEnergy,
@@ -64,7 +159,9 @@ func (cr *ChargeRater) StartCharge(continued bool) {
 func (cr *ChargeRater) StopCharge() {
 	cr.Lock()
 	defer cr.Unlock()
+	defer cr.save()
 
+	cr.restored = false
 	cr.charging = false
 
 	// get end energy amount
@@ -93,9 +190,23 @@ func (cr *ChargeRater) SetChargePower(power float64) {
 		cr.chargedEnergy += power / 1e3 * float64(cr.clck.Since(cr.start)) / float64(time.Hour)
 		// move timestamp
 		cr.start = cr.clck.Now()
+		cr.save()
 	}
 }
 
+// Disconnect signals vehicle disconnect. A restored session is not continued afterwards.
+func (cr *ChargeRater) Disconnect() {
+	cr.Lock()
+	defer cr.Unlock()
+	defer cr.save()
+
+	cr.restored = false
+	cr.unverified = false
+	cr.connected = false
+	cr.charging = false
+	cr.vehicle = ""
+}
+
 // ChargedEnergy implements the ChargeRater interface.
 // It returns energy consumption since charge start in kWh.
 func (cr *ChargeRater) ChargedEnergy() (float64, error) {
@@ -11,7 +11,7 @@ import (
 )
 
 func TestNoMeter(t *testing.T) {
-	cr := NewChargeRater(util.NewLogger("foo"), nil)
+	cr := NewChargeRater(util.NewLogger("foo"), nil, nil)
 	clck := clock.NewMock()
 	cr.clck = clck
 
@@ -70,7 +70,7 @@ func TestWrappedMeter(t *testing.T) {
 
 	cm := &EnergyDecorator{Meter: mm, MeterEnergy: me}
 
-	cr := NewChargeRater(util.NewLogger("foo"), cm)
+	cr := NewChargeRater(util.NewLogger("foo"), cm, nil)
 	clck := clock.NewMock()
 	cr.clck = clck
 
@@ -110,3 +110,92 @@ func TestWrappedMeter(t *testing.T) {
 		t.Errorf("energy: %.1f %v", f, err)
 	}
 }
+
+func TestRestoredMeter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mm := api.NewMockMeter(ctrl)
+	me := api.NewMockMeterEnergy(ctrl)
+
+	type EnergyDecorator struct {
+		api.Meter
+		api.MeterEnergy
+	}
+
+	cm := &EnergyDecorator{Meter: mm, MeterEnergy: me}
+	store := make(mapStore)
+
+	cr := NewChargeRater(util.NewLogger("foo"), cm, store)
+
+	me.EXPECT().TotalEnergy().Return(2.0, nil)
+	cr.StartCharge(false)
+	me.EXPECT().TotalEnergy().Return(2.0, nil)
+	cr.StartCharge(true)
+
+	// restart while charging
+	cr = NewChargeRater(util.NewLogger("foo"), cm, store)
+
+	// startup connect and start events continue the session
+	cr.StartCharge(false)
+	cr.StartCharge(true)
+
+	me.EXPECT().TotalEnergy().Return(5.0, nil)
+
+	if f, err := cr.ChargedEnergy(); f != 3 || err != nil {
+		t.Errorf("energy: %.1f %v", f, err)
+	}
+
+	// restart, vehicle disconnected meanwhile
+	cr = NewChargeRater(util.NewLogger("foo"), cm, store)
+	cr.Disconnect()
+
+	me.EXPECT().TotalEnergy().Return(5.0, nil)
+	cr.StartCharge(false)
+
+	if f, err := cr.ChargedEnergy(); f != 0 || err != nil {
+		t.Errorf("energy: %.1f %v", f, err)
+	}
+
+	// restart after disconnect, new session starts on connect
+	cr.Disconnect()
+	cr = NewChargeRater(util.NewLogger("foo"), cm, store)
+
+	me.EXPECT().TotalEnergy().Return(5.0, nil)
+	cr.StartCharge(false)
+
+	if f, err := cr.ChargedEnergy(); f != 0 || err != nil {
+		t.Errorf("energy: %.1f %v", f, err)
+	}
+
+	// charge vehicle a
+	cr.SetVehicle("a")
+	me.EXPECT().TotalEnergy().Return(5.0, nil)
+	cr.StartCharge(true)
+
+	// restart while charging, same vehicle identified
+	cr = NewChargeRater(util.NewLogger("foo"), cm, store)
+	cr.StartCharge(false)
+	cr.StartCharge(true)
+	cr.SetVehicle("a")
+
+	me.EXPECT().TotalEnergy().Return(6.0, nil)
+
+	if f, err := cr.ChargedEnergy(); f != 1 || err != nil {
+		t.Errorf("energy: %.1f %v", f, err)
+	}
+
+	// restart while charging, other vehicle identified
+	cr = NewChargeRater(util.NewLogger("foo"), cm, store)
+	cr.StartCharge(false)
+	cr.StartCharge(true)
+
+	me.EXPECT().TotalEnergy().Return(7.0, nil)
+	cr.SetVehicle("b")
+
+	me.EXPECT().TotalEnergy().Return(8.0, nil)
+
+	if f, err := cr.ChargedEnergy(); f != 1 || err != nil {
+		t.Errorf("energy: %.1f %v", f, err)
+	}
+}
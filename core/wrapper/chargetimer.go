@@ -5,30 +5,99 @@ import (
 	"time"
 
 	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/util"
 )
 
+// chargeTimerState is the persisted state of a charge timer
+type chargeTimerState struct {
+	Connected bool          `json:"connected"`
+	Vehicle   string        `json:"vehicle"`
+	Charging  bool          `json:"charging"`
+	Start     time.Time     `json:"start"`
+	Duration  time.Duration `json:"duration"`
+}
+
 // ChargeTimer measures charging time between start and stop events
 type ChargeTimer struct {
 	sync.Mutex
+	log  *util.Logger
 	clck clock.Clock
 
-	charging bool
-	start    time.Time
-	duration time.Duration
+	charging   bool
+	start      time.Time
+	duration   time.Duration
+	connected  bool
+	vehicle    string
+	store      StateStore
+	restored   bool // restored session not yet continued
+	unverified bool // restored session not yet matched to the identified vehicle
 }
 
 // NewChargeTimer creates ChargeTimer for tracking duration between
-// start and stop events
-func NewChargeTimer() *ChargeTimer {
-	return &ChargeTimer{
-		clck: clock.New(),
+// start and stop events. If store is not nil, state is restored from
+// and persisted to the store.
+func NewChargeTimer(log *util.Logger, store StateStore) *ChargeTimer {
+	m := &ChargeTimer{
+		log:   log,
+		clck:  clock.New(),
+		store: store,
+	}
+
+	if store != nil {
+		var res chargeTimerState
+		if err := store.Load(keys.ChargeTimerState, &res); err == nil && res.Connected {
+			m.connected = true
+			m.vehicle = res.Vehicle
+			m.charging = res.Charging
+			m.start = res.Start
+			m.duration = res.Duration
+			m.restored = true
+			m.unverified = true
+			m.log.DEBUG.Printf("restored charge duration: %v", m.duration.Round(time.Second))
+		}
 	}
+
+	return m
 }
 
-// StartCharge signals charge timer start
+// save persists the charge timer state
+func (m *ChargeTimer) save() {
+	if m.store == nil {
+		return
+	}
+
+	if err := m.store.Save(keys.ChargeTimerState, chargeTimerState{
+		Connected: m.connected,
+		Vehicle:   m.vehicle,
+		Charging:  m.charging,
+		Start:     m.start,
+		Duration:  m.duration,
+	}); err != nil {
+		m.log.ERROR.Printf("charge timer state: %v", err)
+	}
+}
+
+// StartCharge signals charge timer start.
+// A session restored from the store is continued on the first connect.
 func (m *ChargeTimer) StartCharge(continued bool) {
 	m.Lock()
 	defer m.Unlock()
+	defer m.save()
+
+	m.connected = true
+
+	if m.restored {
+		m.restored = false
+		if !continued {
+			return
+		}
+	}
+
+	// restored while charging
+	if continued && m.charging {
+		return
+	}
 
 	m.start = m.clck.Now()
 
@@ -36,18 +105,52 @@ func (m *ChargeTimer) StartCharge(continued bool) {
 		m.charging = true
 	} else {
 		m.duration = 0
+		m.vehicle = ""
+		m.unverified = false
 	}
 }
 
+// SetVehicle signals the identified vehicle. A restored session of a different vehicle is discarded.
+func (m *ChargeTimer) SetVehicle(vehicle string) {
+	m.Lock()
+	defer m.Unlock()
+	defer m.save()
+
+	if m.unverified && m.vehicle != "" && m.vehicle != vehicle {
+		m.log.DEBUG.Printf("discarding restored charge duration of %s", m.vehicle)
+
+		m.duration = 0
+		m.start = m.clck.Now()
+	}
+
+	m.unverified = false
+	m.vehicle = vehicle
+}
+
 // StopCharge signals charge timer stop
 func (m *ChargeTimer) StopCharge() {
 	m.Lock()
 	defer m.Unlock()
+	defer m.save()
 
+	m.restored = false
 	m.charging = false
 	m.duration += m.clck.Since(m.start)
 }
 
+// Disconnect signals vehicle disconnect. A restored session is not continued afterwards.
+func (m *ChargeTimer) Disconnect() {
+	m.Lock()
+	defer m.Unlock()
+	defer m.save()
+
+	m.restored = false
+	m.unverified = false
+	m.connected = false
+	m.charging = false
+	m.vehicle = ""
+}
+
 // ChargingTime implements the api.ChargeTimer interface
 func (m *ChargeTimer) ChargingTime() (time.Duration, error) {
 	m.Lock()
@@ -1,14 +1,36 @@
 package wrapper
 
 import (
+	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/util"
 )
 
+// mapStore is an in-memory StateStore
+type mapStore map[string]string
+
+func (s mapStore) Save(key string, v any) error {
+	b, err := json.Marshal(v)
+	if err == nil {
+		s[key] = string(b)
+	}
+	return err
+}
+
+func (s mapStore) Load(key string, v any) error {
+	b, ok := s[key]
+	if !ok {
+		return errors.New("not found")
+	}
+	return json.Unmarshal([]byte(b), v)
+}
+
 func TestTimer(t *testing.T) {
-	ct := NewChargeTimer()
+	ct := NewChargeTimer(util.NewLogger("foo"), nil)
 	clck := clock.NewMock()
 	ct.clck = clck
 
@@ -30,3 +52,84 @@ func TestTimer(t *testing.T) {
 		t.Error(d, err)
 	}
 }
+
+func TestTimerRestore(t *testing.T) {
+	store := make(mapStore)
+	clck := clock.NewMock()
+
+	ct := NewChargeTimer(util.NewLogger("foo"), store)
+	ct.clck = clck
+
+	ct.StartCharge(false)
+	ct.StartCharge(true)
+	clck.Add(time.Hour)
+	ct.StopCharge()
+	ct.StartCharge(true)
+	clck.Add(time.Hour)
+
+	// restart while charging
+	ct = NewChargeTimer(util.NewLogger("foo"), store)
+	ct.clck = clck
+
+	// startup connect and start events continue the session
+	ct.StartCharge(false)
+	ct.StartCharge(true)
+	clck.Add(time.Hour)
+
+	if d, err := ct.ChargingTime(); d != 3*time.Hour || err != nil {
+		t.Error(d, err)
+	}
+
+	ct.StopCharge()
+
+	// restart, vehicle disconnected meanwhile
+	ct = NewChargeTimer(util.NewLogger("foo"), store)
+	ct.clck = clck
+
+	if d, err := ct.ChargingTime(); d != 3*time.Hour || err != nil {
+		t.Error(d, err)
+	}
+
+	ct.Disconnect()
+	ct.StartCharge(false)
+
+	if d, err := ct.ChargingTime(); d != 0 || err != nil {
+		t.Error(d, err)
+	}
+
+	// charge vehicle a
+	ct.SetVehicle("a")
+	ct.StartCharge(true)
+	clck.Add(time.Hour)
+
+	// restart while charging, other vehicle identified
+	ct = NewChargeTimer(util.NewLogger("foo"), store)
+	ct.clck = clck
+
+	ct.StartCharge(false)
+	ct.StartCharge(true)
+	clck.Add(time.Hour)
+
+	if d, err := ct.ChargingTime(); d != 2*time.Hour || err != nil {
+		t.Error(d, err)
+	}
+
+	ct.SetVehicle("b")
+	clck.Add(time.Hour)
+
+	if d, err := ct.ChargingTime(); d != time.Hour || err != nil {
+		t.Error(d, err)
+	}
+
+	// restart after disconnect, new session starts on connect
+	ct.StopCharge()
+	ct.Disconnect()
+
+	ct = NewChargeTimer(util.NewLogger("foo"), store)
+	ct.clck = clck
+	ct.StartCharge(false)
+
+	if d, err := ct.ChargingTime(); d != 0 || err != nil {
+		t.Error(d, err)
+	}
+}
@@ -0,0 +1,7 @@
+package wrapper
+
+// StateStore persists wrapper state across restarts
+type StateStore interface {
+	Save(key string, v any) error
+	Load(key string, v any) error
+}
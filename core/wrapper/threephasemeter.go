@@ -0,0 +1,42 @@
+package wrapper
+
+import (
+	"github.com/evcc-io/evcc/api"
+)
+
+// ThreePhaseMeter is a replacement for a physical charge meter.
+// It calculates power consumption from the charger's per-phase currents.
+type ThreePhaseMeter struct {
+	meter   api.PhaseCurrents
+	voltage func() float64
+}
+
+// NewThreePhaseMeter creates a meter using the phase currents and nominal voltage
+func NewThreePhaseMeter(meter api.PhaseCurrents, voltage func() float64) *ThreePhaseMeter {
+	return &ThreePhaseMeter{
+		meter:   meter,
+		voltage: voltage,
+	}
+}
+
+// CurrentPower implements the api.Meter interface
+func (m *ThreePhaseMeter) CurrentPower() (float64, error) {
+	i1, i2, i3, err := m.meter.Currents()
+	if err != nil {
+		return 0, err
+	}
+
+	// use measured voltages if available
+	if vm, ok := m.meter.(api.PhaseVoltages); ok {
+		if u1, u2, u3, err := vm.Voltages(); err == nil && u1*u2*u3 > 0 {
+			return i1*u1 + i2*u2 + i3*u3, nil
+		}
+	}
+
+	return (i1 + i2 + i3) * m.voltage(), nil
+}
+
+// Currents implements the api.PhaseCurrents interface
+func (m *ThreePhaseMeter) Currents() (float64, float64, float64, error) {
+	return m.meter.Currents()
+}
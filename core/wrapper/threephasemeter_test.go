@@ -0,0 +1,46 @@
+package wrapper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type currents struct {
+	i []float64
+}
+
+func (m *currents) Currents() (float64, float64, float64, error) {
+	return m.i[0], m.i[1], m.i[2], nil
+}
+
+type currentsVoltages struct {
+	currents
+	u []float64
+}
+
+func (m *currentsVoltages) Voltages() (float64, float64, float64, error) {
+	return m.u[0], m.u[1], m.u[2], nil
+}
+
+func TestThreePhaseMeter(t *testing.T) {
+	voltage := func() float64 { return 230 }
+
+	m := NewThreePhaseMeter(&currents{[]float64{10, 10, 0}}, voltage)
+	p, err := m.CurrentPower()
+	require.NoError(t, err)
+	assert.Equal(t, 4600.0, p)
+
+	// measured voltages
+	m = NewThreePhaseMeter(&currentsVoltages{currents{[]float64{10, 10, 0}}, []float64{220, 240, 230}}, voltage)
+	p, err = m.CurrentPower()
+	require.NoError(t, err)
+	assert.Equal(t, 4600.0, p)
+
+	// incomplete voltages fall back to nominal
+	m = NewThreePhaseMeter(&currentsVoltages{currents{[]float64{16, 0, 0}}, []float64{0, 0, 0}}, voltage)
+	p, err = m.CurrentPower()
+	require.NoError(t, err)
+	assert.Equal(t, 3680.0, p)
+}
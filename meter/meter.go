@@ -13,16 +13,17 @@ func init() {
 	registry.Add(api.Custom, NewConfigurableFromConfig)
 }
 
-//go:generate go run ../cmd/tools/decorate.go -f decorateMeter -b api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)" -t "api.PhaseVoltages,Voltages,func() (float64, float64, float64, error)" -t "api.PhasePowers,Powers,func() (float64, float64, float64, error)" -t "api.Battery,Soc,func() (float64, error)" -t "api.BatteryCapacity,Capacity,func() float64" -t "api.BatteryController,SetBatteryMode,func(api.BatteryMode) error"
+//go:generate go run ../cmd/tools/decorate.go -f decorateMeter -b api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)" -t "api.PhaseVoltages,Voltages,func() (float64, float64, float64, error)" -t "api.PhasePowers,Powers,func() (float64, float64, float64, error)" -t "api.Battery,Soc,func() (float64, error)" -t "api.BatteryCapacity,Capacity,func() float64" -t "api.BatteryController,SetBatteryMode,func(api.BatteryMode) error" -t "api.FrequencyMeter,Frequency,func() (float64, error)"
 
 // NewConfigurableFromConfig creates api.Meter from config
 func NewConfigurableFromConfig(other map[string]interface{}) (api.Meter, error) {
 	cc := struct {
-		Power    provider.Config
-		Energy   *provider.Config  // optional
-		Currents []provider.Config // optional
-		Voltages []provider.Config // optional
-		Powers   []provider.Config // optional
+		Power     provider.Config
+		Energy    *provider.Config  // optional
+		Currents  []provider.Config // optional
+		Voltages  []provider.Config // optional
+		Powers    []provider.Config // optional
+		Frequency *provider.Config  // optional
 
 		// battery
 		capacity    `mapstructure:",squash"`
@@ -75,6 +76,15 @@ func NewConfigurableFromConfig(other map[string]interface{}) (api.Meter, error)
 		return nil, fmt.Errorf("powers: %w", err)
 	}
 
+	// decorate frequency
+	var frequencyG func() (float64, error)
+	if cc.Frequency != nil {
+		frequencyG, err = provider.NewFloatGetterFromConfig(*cc.Frequency)
+		if err != nil {
+			return nil, fmt.Errorf("frequency: %w", err)
+		}
+	}
+
 	// decorate soc
 	var socG func() (float64, error)
 	if cc.Soc != nil {
@@ -104,7 +114,7 @@ func NewConfigurableFromConfig(other map[string]interface{}) (api.Meter, error)
 		batModeS = cc.battery.ModeController(modeS)
 	}
 
-	res := m.Decorate(totalEnergyG, currentsG, voltagesG, powersG, socG, cc.capacity.Decorator(), batModeS)
+	res := m.Decorate(totalEnergyG, currentsG, voltagesG, powersG, socG, cc.capacity.Decorator(), batModeS, frequencyG)
 
 	return res, nil
 }
@@ -171,8 +181,9 @@ func (m *Meter) Decorate(
 	batterySoc func() (float64, error),
 	capacity func() float64,
 	setBatteryMode func(api.BatteryMode) error,
+	frequency func() (float64, error),
 ) api.Meter {
-	return decorateMeter(m, totalEnergy, currents, voltages, powers, batterySoc, capacity, setBatteryMode)
+	return decorateMeter(m, totalEnergy, currents, voltages, powers, batterySoc, capacity, setBatteryMode, frequency)
 }
 
 // CurrentPower implements the api.Meter interface
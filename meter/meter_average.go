@@ -68,7 +68,13 @@ func NewMovingAverageFromConfig(other map[string]interface{}) (api.Meter, error)
 		powers = m.Powers
 	}
 
-	return meter.Decorate(totalEnergy, currents, voltages, powers, batterySoc, cc.Meter.capacity.Decorator(), nil), nil
+	// decorate frequency reading
+	var frequency func() (float64, error)
+	if m, ok := m.(api.FrequencyMeter); ok {
+		frequency = m.Frequency
+	}
+
+	return meter.Decorate(totalEnergy, currents, voltages, powers, batterySoc, cc.Meter.capacity.Decorator(), nil, frequency), nil
 }
 
 type MovingAverage struct {
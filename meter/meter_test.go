@@ -0,0 +1,32 @@
+package meter
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigurableFrequency(t *testing.T) {
+	power := map[string]interface{}{"source": "const", "value": 1000}
+
+	m, err := NewConfigurableFromConfig(map[string]interface{}{"power": power})
+	require.NoError(t, err)
+
+	_, ok := m.(api.FrequencyMeter)
+	assert.False(t, ok)
+
+	m, err = NewConfigurableFromConfig(map[string]interface{}{
+		"power":     power,
+		"frequency": map[string]interface{}{"source": "const", "value": 50.25},
+	})
+	require.NoError(t, err)
+
+	fm, ok := m.(api.FrequencyMeter)
+	require.True(t, ok)
+
+	f, err := fm.Frequency()
+	require.NoError(t, err)
+	assert.Equal(t, 50.25, f)
+}
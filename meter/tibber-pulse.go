@@ -27,13 +27,17 @@ type Tibber struct {
 	live          tibber.LiveMeasurement
 	url           string
 	token, homeID string
+	timeout       time.Duration
 	client        *graphql.SubscriptionClient
 }
 
 func NewTibberFromConfig(other map[string]interface{}) (api.Meter, error) {
-	var cc struct {
-		Token  string
-		HomeID string
+	cc := struct {
+		Token   string
+		HomeID  string
+		Timeout time.Duration
+	}{
+		Timeout: time.Minute,
 	}
 
 	if err := util.DecodeOther(other, &cc); err != nil {
@@ -71,10 +75,11 @@ func NewTibberFromConfig(other map[string]interface{}) (api.Meter, error) {
 	}
 
 	t := &Tibber{
-		log:    log,
-		url:    res.Viewer.WebsocketSubscriptionUrl,
-		token:  cc.Token,
-		homeID: cc.HomeID,
+		log:     log,
+		url:     res.Viewer.WebsocketSubscriptionUrl,
+		token:   cc.Token,
+		homeID:  cc.HomeID,
+		timeout: cc.Timeout,
 	}
 
 	// run the client
@@ -150,11 +155,10 @@ func (t *Tibber) subscribe(done chan error) {
 	}()
 }
 
+// reconnect re-establishes the subscription if no measurement has been received within timeout
 func (t *Tibber) reconnect() error {
-	const timeout = time.Minute
-
 	t.mu.Lock()
-	if time.Since(t.updated) <= timeout {
+	if time.Since(t.updated) <= t.timeout {
 		t.mu.Unlock()
 		return nil
 	}
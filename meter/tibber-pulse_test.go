@@ -0,0 +1,107 @@
+package meter
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+)
+
+type pulseMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// pulseServer streams fake live measurements using the graphql-transport-ws protocol
+func pulseServer(t *testing.T, connections *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+			Subprotocols: []string{"graphql-transport-ws"},
+		})
+		if err != nil {
+			return
+		}
+		defer conn.CloseNow()
+
+		ctx := r.Context()
+		n := connections.Add(1)
+
+		var init pulseMessage
+		if err := wsjson.Read(ctx, conn, &init); err != nil || init.Type != "connection_init" {
+			return
+		}
+		if err := wsjson.Write(ctx, conn, pulseMessage{Type: "connection_ack"}); err != nil {
+			return
+		}
+
+		var sub pulseMessage
+		if err := wsjson.Read(ctx, conn, &sub); err != nil || sub.Type != "subscribe" {
+			return
+		}
+		assert.Contains(t, string(sub.Payload), "liveMeasurement")
+
+		// first connection is dropped after a single measurement
+		count := 10
+		if n == 1 {
+			count = 1
+		}
+
+		for i := 0; i < count; i++ {
+			payload := `{"data":{"liveMeasurement":{"timestamp":"2024-01-01T00:00:00Z","power":1500,"powerProduction":500,"currentL1":1.1,"currentL2":2.2,"currentL3":3.3}}}`
+			if n > 1 {
+				payload = strings.Replace(payload, `"power":1500`, `"power":2500`, 1)
+			}
+
+			if err := wsjson.Write(ctx, conn, pulseMessage{ID: sub.ID, Type: "next", Payload: json.RawMessage(payload)}); err != nil {
+				return
+			}
+
+			time.Sleep(10 * time.Millisecond)
+		}
+	}))
+}
+
+func TestTibberPulse(t *testing.T) {
+	var connections atomic.Int32
+
+	srv := pulseServer(t, &connections)
+	defer srv.Close()
+
+	m := &Tibber{
+		log:     util.NewLogger("foo"),
+		url:     "ws" + strings.TrimPrefix(srv.URL, "http"),
+		token:   "token",
+		homeID:  "home",
+		timeout: 100 * time.Millisecond,
+	}
+
+	require.NoError(t, m.reconnect())
+
+	power, err := m.CurrentPower()
+	require.NoError(t, err)
+	assert.Equal(t, 1000.0, power)
+
+	l1, l2, l3, err := m.Currents()
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1.1, 2.2, 3.3}, []float64{l1, l2, l3})
+
+	// stale measurement triggers reconnect
+	time.Sleep(2 * m.timeout)
+
+	power, err = m.CurrentPower()
+	require.NoError(t, err)
+	assert.Equal(t, 2000.0, power)
+	assert.GreaterOrEqual(t, connections.Load(), int32(2))
+
+	require.NoError(t, m.client.Close())
+}
@@ -14,6 +14,7 @@ import (
 type Event struct {
 	Loadpoint *int // optional loadpoint id
 	Event     string
+	Data      map[string]interface{} // optional event attributes, take precedence over cached values
 }
 
 // EventTemplateConfig is the push message configuration for an event
@@ -76,6 +77,11 @@ func (h *Hub) apply(ev Event, tmpl string) (string, error) {
 		}
 	}
 
+	// event attributes
+	for k, v := range ev.Data {
+		attr[k] = v
+	}
+
 	// add missing attributes
 	if name, ok := attr["vehicleName"].(string); ok {
 		if v, err := h.vehicles.ByName(name); err == nil {
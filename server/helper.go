@@ -14,6 +14,11 @@ func pass[T any](f func(T)) func(T) error {
 	}
 }
 
+// parseInt64 parses decimal int64 values
+func parseInt64(payload string) (int64, error) {
+	return strconv.ParseInt(payload, 10, 64)
+}
+
 // parseFloat rejects NaN and Inf values
 func parseFloat(payload string) (float64, error) {
 	f, err := strconv.ParseFloat(payload, 64)
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	eapi "github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/aggregator"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/server/assets"
 	"github.com/evcc-io/evcc/util"
@@ -119,6 +120,8 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, cache *util.Cache) {
 		"smartcost":               {"POST", "/smartcostlimit/{value:[-0-9.]+}", updateSmartCostLimit(site)},
 		"tariff":                  {"GET", "/tariff/{tariff:[a-z]+}", tariffHandler(site)},
 		"sessions":                {"GET", "/sessions", sessionHandler},
+		"lifetimesolar":           {"GET", "/stats/lifetime-solar-to-ev", lifetimeSolarEnergyHandler(site)},
+		"lifetimesolar2":          {"DELETE", "/stats/lifetime-solar-to-ev", lifetimeSolarEnergyHandler(site)},
 		"updatesession":           {"PUT", "/session/{id:[0-9]+}", updateSessionHandler},
 		"deletesession":           {"DELETE", "/session/{id:[0-9]+}", deleteSessionHandler},
 		"telemetry":               {"GET", "/settings/telemetry", boolGetHandler(telemetry.Enabled)},
@@ -152,20 +155,34 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, cache *util.Cache) {
 		api := api.PathPrefix(fmt.Sprintf("/loadpoints/%d", id+1)).Subrouter()
 
 		routes := map[string]route{
-			"mode":             {"POST", "/mode/{value:[a-z]+}", handler(eapi.ChargeModeString, pass(lp.SetMode), lp.GetMode)},
+			"mode":             {"POST", "/mode/{value:[a-z]+}", handler(eapi.ChargeModeString, lp.SetMode, lp.GetMode)},
+			"mode2":            {"GET", "/mode", getHandler(lp.GetMode)},
+			"mode3":            {"PUT", "/mode", bodyHandler("mode", lp.SetMode, lp.GetMode)},
+			"soctarget":        {"GET", "/socTarget", getHandler(lp.GetSocTarget)},
+			"soctarget2":       {"PUT", "/socTarget", bodyHandler("socTarget", lp.SetSocTarget, lp.GetSocTarget)},
+			"status":           {"GET", "/status", loadpointStatusHandler(lp)},
 			"limitsoc":         {"POST", "/limitsoc/{value:[0-9]+}", intHandler(pass(lp.SetLimitSoc), lp.GetLimitSoc)},
-			"limitenergy":      {"POST", "/limitenergy/{value:[0-9.]+}", floatHandler(pass(lp.SetLimitEnergy), lp.GetLimitEnergy)},
+			"limitenergy":      {"POST", "/limitenergy/{value:[0-9.]+}", floatHandler(lp.SetLimitEnergy, lp.GetLimitEnergy)},
 			"mincurrent":       {"POST", "/mincurrent/{value:[0-9.]+}", floatHandler(lp.SetMinCurrent, lp.GetMinCurrent)},
 			"maxcurrent":       {"POST", "/maxcurrent/{value:[0-9.]+}", floatHandler(lp.SetMaxCurrent, lp.GetMaxCurrent)},
 			"phases":           {"POST", "/phases/{value:[0-9]+}", intHandler(lp.SetPhases, lp.GetPhases)},
 			"plan":             {"GET", "/plan", planHandler(lp)},
+			"events":           {"GET", "/events", getHandler(lp.EventHistory)},
+			"export":           {"GET", "/export", vehicleDataExportHandler(lp)},
 			"planpreview":      {"GET", "/plan/preview/{type:(?:soc|energy)}/{value:[0-9.]+}/{time:[0-9TZ:.-]+}", planPreviewHandler(lp)},
 			"planenergy":       {"POST", "/plan/energy/{value:[0-9.]+}/{time:[0-9TZ:.-]+}", planEnergyHandler(lp)},
 			"planenergy2":      {"DELETE", "/plan/energy", planRemoveHandler(lp)},
+			"planonetime":      {"POST", "/plan/onetime/{value:[0-9.]+}/{time:[0-9TZ:.-]+}", planOneTimeHandler(lp)},
+			"planonetime2":     {"DELETE", "/plan/onetime", planOneTimeRemoveHandler(lp)},
+			"departure":        {"POST", "/departure/{time:[0-9TZ:.-]+}", departureHandler(lp)},
+			"departure2":       {"DELETE", "/departure", departureRemoveHandler(lp)},
 			"vehicle":          {"POST", "/vehicle/{name:[a-zA-Z0-9_.:-]+}", vehicleSelectHandler(site, lp)},
 			"vehicle2":         {"DELETE", "/vehicle", vehicleRemoveHandler(lp)},
 			"vehicleDetect":    {"PATCH", "/vehicle", vehicleDetectHandler(lp)},
 			"remotedemand":     {"POST", "/remotedemand/{demand:[a-z]+}/{source:[0-9a-zA-Z_-]+}", remoteDemandHandler(lp)},
+			"remotestart":      {"POST", "/remotestart/{token:[0-9a-zA-Z_-]+}", remoteStartHandler(lp)},
+			"forcecharge":      {"POST", "/forcecharge/{value:[a-z]+}", boolHandler(pass(lp.SetForceCharge), lp.GetForceCharge)},
+			"externalcurrent":  {"POST", "/externalcurrent/{value:[0-9]+}", handler(parseInt64, lp.SetExternalCurrent, lp.GetExternalCurrent)},
 			"enableThreshold":  {"POST", "/enable/threshold/{value:-?[0-9.]+}", floatHandler(pass(lp.SetEnableThreshold), lp.GetEnableThreshold)},
 			"disableThreshold": {"POST", "/disable/threshold/{value:-?[0-9.]+}", floatHandler(pass(lp.SetDisableThreshold), lp.GetDisableThreshold)},
 			"smartCostLimit":   {"POST", "/smartcostlimit/{value:[0-9.]+}", floatHandler(pass(lp.SetSmartCostLimit), lp.GetSmartCostLimit)},
@@ -202,3 +219,20 @@ func (s *HTTPd) RegisterShutdownHandler(callback func()) {
 		api.Methods(r.Methods()...).Path(r.Pattern).Handler(r.HandlerFunc)
 	}
 }
+
+// RegisterAggregateHandler connects the http handlers to the site aggregator
+func (s *HTTPd) RegisterAggregateHandler(agg *aggregator.SiteAggregator) {
+	router := s.Server.Handler.(*mux.Router)
+
+	// api
+	api := router.PathPrefix("/api").Subrouter()
+	api.Use(jsonHandler)
+	api.Use(handlers.CompressHandler)
+	api.Use(handlers.CORS(
+		handlers.AllowedHeaders([]string{"Content-Type"}),
+	))
+
+	api.Methods("GET").Path("/aggregate").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonResult(w, agg.Aggregate())
+	})
+}
@@ -1,10 +1,19 @@
 package server
 
 import (
+	"context"
+	"errors"
+	"io"
 	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
 )
 
 func TestNaNInf(t *testing.T) {
@@ -15,3 +24,62 @@ func TestNaNInf(t *testing.T) {
 	encodeFloats(c)
 	assert.Equal(t, map[string]any{"foo": nil, "bar": nil}, c, "NaN not encoded as nil")
 }
+
+func TestBodyHandler(t *testing.T) {
+	mode := api.ModePV
+	h := bodyHandler("mode", func(m api.ChargeMode) error {
+		mode = m
+		return nil
+	}, func() api.ChargeMode {
+		return mode
+	})
+
+	for _, tc := range []struct {
+		body   string
+		status int
+		mode   api.ChargeMode
+	}{
+		{`{"mode":"now"}`, http.StatusOK, api.ModeNow},
+		{`{"mode":"foo"}`, http.StatusBadRequest, api.ModeNow},
+		{`{"soc":80}`, http.StatusBadRequest, api.ModeNow},
+		{`mode`, http.StatusBadRequest, api.ModeNow},
+		{`{"mode":"minpv"}`, http.StatusOK, api.ModeMinPV},
+	} {
+		t.Log(tc)
+
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest(http.MethodPut, "/mode", strings.NewReader(tc.body)))
+
+		assert.Equal(t, tc.status, w.Code)
+		assert.Equal(t, tc.mode, mode)
+
+		if tc.status == http.StatusOK {
+			assert.JSONEq(t, `{"result":"`+string(tc.mode)+`"}`, w.Body.String())
+		}
+	}
+}
+
+func TestVehicleDataExportHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	lp := loadpoint.NewMockAPI(ctrl)
+	h := vehicleDataExportHandler(lp)
+
+	lp.EXPECT().ExportVehicleData(gomock.Any(), gomock.Any(), "csv").DoAndReturn(func(_ context.Context, w io.Writer, _ string) error {
+		_, err := w.Write([]byte("foo"))
+		return err
+	})
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/export?format=csv", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	assert.Equal(t, "foo", w.Body.String())
+
+	lp.EXPECT().ExportVehicleData(gomock.Any(), gomock.Any(), "xlsx").Return(errors.New("invalid export format"))
+
+	w = httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/export?format=xlsx", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"net/http"
@@ -39,6 +40,20 @@ func remoteDemandHandler(lp loadpoint.API) http.HandlerFunc {
 	}
 }
 
+// remoteStartHandler starts a remotely authorized session
+func remoteStartHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		if err := lp.RemoteStart(vars["token"]); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonResult(w, true)
+	}
+}
+
 // planHandler returns the current plan
 func planHandler(lp loadpoint.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -175,6 +190,113 @@ func planRemoveHandler(lp loadpoint.API) http.HandlerFunc {
 	}
 }
 
+// planOneTimeHandler sets a one-time plan for the current session
+func planOneTimeHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		ts, err := time.Parse(time.RFC3339, vars["time"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		val, err := strconv.ParseFloat(vars["value"], 64)
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := lp.SetOneTimeSchedule(ts, val); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res := struct {
+			Soc  float64   `json:"soc"`
+			Time time.Time `json:"time"`
+		}{
+			Soc:  val,
+			Time: ts,
+		}
+
+		jsonResult(w, res)
+	}
+}
+
+// planOneTimeRemoveHandler removes the one-time plan
+func planOneTimeRemoveHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := lp.SetOneTimeSchedule(time.Time{}, 0); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res := struct{}{}
+		jsonResult(w, res)
+	}
+}
+
+// loadpointStatusHandler returns the loadpoint charging state
+func loadpointStatusHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res := struct {
+			Status       api.ChargeStatus `json:"status"`
+			Mode         api.ChargeMode   `json:"mode"`
+			ChargePower  float64          `json:"chargePower"`
+			ActivePhases int              `json:"activePhases"`
+			SocTarget    int              `json:"socTarget"`
+		}{
+			Status:       lp.GetStatus(),
+			Mode:         lp.GetMode(),
+			ChargePower:  lp.GetChargePower(),
+			ActivePhases: lp.ActivePhases(),
+			SocTarget:    lp.GetSocTarget(),
+		}
+
+		jsonResult(w, res)
+	}
+}
+
+// departureHandler sets the departure time for scheduled charging
+func departureHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		ts, err := time.Parse(time.RFC3339, vars["time"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := lp.SetDepartureTime(ts); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res := struct {
+			Time time.Time `json:"time"`
+		}{
+			Time: lp.GetDepartureTime(),
+		}
+
+		jsonResult(w, res)
+	}
+}
+
+// departureRemoveHandler removes the departure time
+func departureRemoveHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := lp.SetDepartureTime(time.Time{}); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res := struct{}{}
+		jsonResult(w, res)
+	}
+}
+
 // vehicleSelectHandler sets active vehicle
 func vehicleSelectHandler(site site.API, lp loadpoint.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -216,3 +338,28 @@ func vehicleDetectHandler(lp loadpoint.API) http.HandlerFunc {
 		jsonResult(w, res)
 	}
 }
+
+// vehicleDataExportHandler exports the loadpoint's sessions, soc history and charging curve
+func vehicleDataExportHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = "json"
+		}
+
+		var buf bytes.Buffer
+		if err := lp.ExportVehicleData(localeContext(r), &buf, format); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		contentType := "application/json"
+		if format == "csv" {
+			contentType = "text/csv"
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", `attachment; filename="vehicledata.`+format+`"`)
+		_, _ = w.Write(buf.Bytes())
+	}
+}
@@ -126,6 +126,37 @@ func boolGetHandler(get func() bool) http.HandlerFunc {
 	}
 }
 
+// getHandler retrieves api values
+func getHandler[T any](get func() T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jsonResult(w, get())
+	}
+}
+
+// bodyHandler updates api values from a JSON body of the form {"<key>": <value>}
+func bodyHandler[T any](key string, set func(T) error, get func() T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]T
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		val, ok := req[key]
+		if !ok {
+			jsonError(w, http.StatusBadRequest, fmt.Errorf("missing %s", key))
+			return
+		}
+
+		if err := set(val); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonResult(w, get())
+	}
+}
+
 // encodeFloats replaces NaN and Inf with nil
 // TODO handle hierarchical data
 func encodeFloats(data map[string]any) {
@@ -158,6 +189,21 @@ func updateSmartCostLimit(site site.API) http.HandlerFunc {
 	}
 }
 
+// lifetimeSolarEnergyHandler returns the solar energy delivered to vehicles since installation
+func lifetimeSolarEnergyHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var res float64
+		for _, lp := range site.Loadpoints() {
+			if r.Method == http.MethodDelete {
+				lp.ResetLifetimeSolarEnergy()
+			}
+			res += lp.GetLifetimeSolarEnergy()
+		}
+
+		jsonResult(w, res)
+	}
+}
+
 // stateHandler returns the combined state
 func stateHandler(cache *util.Cache) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
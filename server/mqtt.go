@@ -185,15 +185,17 @@ func (m *MQTT) listenSiteSetters(topic string, site site.API) error {
 
 func (m *MQTT) listenLoadpointSetters(topic string, site site.API, lp loadpoint.API) error {
 	for _, s := range []setter{
-		{"/mode", setterFunc(api.ChargeModeString, pass(lp.SetMode))},
+		{"/mode", setterFunc(api.ChargeModeString, lp.SetMode)},
 		{"/phases", intSetter(lp.SetPhases)},
 		{"/limitSoc", intSetter(pass(lp.SetLimitSoc))},
 		{"/minCurrent", floatSetter(lp.SetMinCurrent)},
 		{"/maxCurrent", floatSetter(lp.SetMaxCurrent)},
-		{"/limitEnergy", floatSetter(pass(lp.SetLimitEnergy))},
+		{"/limitEnergy", floatSetter(lp.SetLimitEnergy)},
 		{"/enableThreshold", floatSetter(pass(lp.SetEnableThreshold))},
 		{"/disableThreshold", floatSetter(pass(lp.SetDisableThreshold))},
 		{"/smartCostLimit", floatSetter(pass(lp.SetSmartCostLimit))},
+		{"/externalCurrent", setterFunc(parseInt64, lp.SetExternalCurrent)},
+		{"/forceCharge", setterFunc(strconv.ParseBool, pass(lp.SetForceCharge))},
 		{"/planEnergy", func(payload string) error {
 			var plan struct {
 				Time  time.Time `json:"time"`
@@ -0,0 +1,93 @@
+package util
+
+import (
+	"errors"
+	"reflect"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	busPublishedMetric *prometheus.CounterVec
+	busReceivedMetric  *prometheus.CounterVec
+)
+
+func init() {
+	labels := []string{"topic"}
+
+	busPublishedMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "evcc",
+		Subsystem: "eventbus",
+		Name:      "published_total",
+		Help:      "Total count of published events",
+	}, labels)
+
+	busReceivedMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "evcc",
+		Subsystem: "eventbus",
+		Name:      "received_total",
+		Help:      "Total count of events received by subscribers",
+	}, labels)
+
+	prometheus.MustRegister(busPublishedMetric, busReceivedMetric)
+}
+
+// InstrumentedBus is an event bus counting published and received events per topic
+type InstrumentedBus struct {
+	evbus.Bus
+}
+
+// NewInstrumentedBus creates an instrumented event bus
+func NewInstrumentedBus() evbus.Bus {
+	return &InstrumentedBus{
+		Bus: evbus.New(),
+	}
+}
+
+// wrap returns a handler of identical signature that counts received events
+func (b *InstrumentedBus) wrap(topic string, fn interface{}) interface{} {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func {
+		// let the bus report the error
+		return fn
+	}
+
+	counter := busReceivedMetric.WithLabelValues(topic)
+	return reflect.MakeFunc(v.Type(), func(args []reflect.Value) []reflect.Value {
+		counter.Inc()
+		return v.Call(args)
+	}).Interface()
+}
+
+// Publish implements the evbus.BusPublisher interface
+func (b *InstrumentedBus) Publish(topic string, args ...interface{}) {
+	busPublishedMetric.WithLabelValues(topic).Inc()
+	b.Bus.Publish(topic, args...)
+}
+
+// Subscribe implements the evbus.BusSubscriber interface
+func (b *InstrumentedBus) Subscribe(topic string, fn interface{}) error {
+	return b.Bus.Subscribe(topic, b.wrap(topic, fn))
+}
+
+// SubscribeAsync implements the evbus.BusSubscriber interface
+func (b *InstrumentedBus) SubscribeAsync(topic string, fn interface{}, transactional bool) error {
+	return b.Bus.SubscribeAsync(topic, b.wrap(topic, fn), transactional)
+}
+
+// SubscribeOnce implements the evbus.BusSubscriber interface
+func (b *InstrumentedBus) SubscribeOnce(topic string, fn interface{}) error {
+	return b.Bus.SubscribeOnce(topic, b.wrap(topic, fn))
+}
+
+// SubscribeOnceAsync implements the evbus.BusSubscriber interface
+func (b *InstrumentedBus) SubscribeOnceAsync(topic string, fn interface{}) error {
+	return b.Bus.SubscribeOnceAsync(topic, b.wrap(topic, fn))
+}
+
+// Unsubscribe implements the evbus.BusSubscriber interface.
+// Instrumented handlers cannot be identified and are therefore not unsubscribed.
+func (b *InstrumentedBus) Unsubscribe(topic string, fn interface{}) error {
+	return errors.New("unsubscribe not supported by instrumented bus")
+}
@@ -0,0 +1,39 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrumentedBus(t *testing.T) {
+	bus := NewInstrumentedBus()
+
+	published := func(topic string) float64 {
+		return testutil.ToFloat64(busPublishedMetric.WithLabelValues(topic))
+	}
+	received := func(topic string) float64 {
+		return testutil.ToFloat64(busReceivedMetric.WithLabelValues(topic))
+	}
+
+	var sum int
+	require.NoError(t, bus.Subscribe("test:add", func(i int) { sum += i }))
+	require.NoError(t, bus.Subscribe("test:add", func(i int) { sum += 10 * i }))
+	require.NoError(t, bus.Subscribe("test:other", func() {}))
+
+	bus.Publish("test:add", 1)
+	bus.Publish("test:add", 2)
+	bus.Publish("test:none")
+
+	assert.Equal(t, 33, sum, "handlers called with arguments")
+
+	assert.Equal(t, 2.0, published("test:add"))
+	assert.Equal(t, 4.0, received("test:add"))
+	assert.Equal(t, 1.0, published("test:none"))
+	assert.Equal(t, 0.0, received("test:none"))
+	assert.Equal(t, 0.0, published("test:other"))
+
+	assert.Error(t, bus.Subscribe("test:invalid", 42))
+}
@@ -90,6 +90,13 @@ func (mb *Connection) Timeout(timeout time.Duration) {
 	mb.conn.Timeout(timeout)
 }
 
+// Close closes the underlying connection. It is re-opened by the next bus operation.
+func (mb *Connection) Close() {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	mb.conn.Close()
+}
+
 // ReadCoils wraps the underlying implementation
 func (mb *Connection) ReadCoilsWithSlave(slaveID uint8, address, quantity uint16) ([]byte, error) {
 	mb.mu.Lock()
@@ -0,0 +1,100 @@
+package vehicle
+
+import (
+	"errors"
+
+	"github.com/enbility/cemd/emobility"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/charger/eebus"
+	"github.com/evcc-io/evcc/util"
+)
+
+// EEBus is an api.Vehicle implementation reading vehicle data reported via ISO 15118 by an EEBus charger
+type EEBus struct {
+	*embed
+	emobility emobility.EmobilityI
+}
+
+func init() {
+	registry.Add("eebus", NewEEBusFromConfig)
+}
+
+// NewEEBusFromConfig creates a new vehicle
+func NewEEBusFromConfig(other map[string]interface{}) (api.Vehicle, error) {
+	cc := struct {
+		embed `mapstructure:",squash"`
+		Ski   string
+	}{}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Ski == "" {
+		return nil, errors.New("missing ski")
+	}
+
+	if eebus.Instance == nil {
+		return nil, errors.New("eebus not configured")
+	}
+
+	// the evse must be configured as eebus charger
+	evse, err := eebus.Instance.EVSE(cc.Ski)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &EEBus{
+		embed:     &cc.embed,
+		emobility: evse,
+	}
+
+	return v, nil
+}
+
+// Soc implements the api.Vehicle interface
+func (v *EEBus) Soc() (float64, error) {
+	if !v.emobility.EVConnected() {
+		return 0, api.ErrNotAvailable
+	}
+
+	if socSupported, err := v.emobility.EVSoCSupported(); err != nil || !socSupported {
+		return 0, api.ErrNotAvailable
+	}
+
+	soc, err := v.emobility.EVSoC()
+	if err != nil {
+		return 0, api.ErrNotAvailable
+	}
+
+	return soc, nil
+}
+
+// Capacity implements the api.Vehicle interface.
+// Without configured capacity, it is derived from the vehicle's maximum energy demand and soc.
+func (v *EEBus) Capacity() float64 {
+	if v.Capacity_ > 0 || !v.emobility.EVConnected() {
+		return v.Capacity_
+	}
+
+	demand, err := v.emobility.EVEnergyDemand()
+	if err != nil {
+		return 0
+	}
+
+	soc, err := v.Soc()
+	if err != nil {
+		return 0
+	}
+
+	return capacityFromDemand(demand.MaxDemand, soc)
+}
+
+// capacityFromDemand returns the battery capacity in kWh from the energy in Wh required to reach 100% soc
+func capacityFromDemand(maxDemand, soc float64) float64 {
+	if maxDemand <= 0 || soc >= 100 {
+		return 0
+	}
+
+	return maxDemand / 1e3 / (1 - soc/100)
+}
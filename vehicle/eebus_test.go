@@ -0,0 +1,22 @@
+package vehicle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEEBusCapacityFromDemand(t *testing.T) {
+	for _, tc := range []struct {
+		maxDemand, soc, capacity float64
+	}{
+		{60000, 0, 60},
+		{30000, 50, 60},
+		{15000, 75, 60},
+		{0, 50, 0},
+		{1000, 100, 0},
+	} {
+		t.Log(tc)
+		assert.InDelta(t, tc.capacity, capacityFromDemand(tc.maxDemand, tc.soc), 1e-9)
+	}
+}
@@ -131,6 +131,17 @@ func (v *Provider) TargetSoc() (float64, error) {
 	return float64(res.Response.ChargeState.ChargeLimitSoc), nil
 }
 
+var _ api.VehicleCurrentLimit = (*Provider)(nil)
+
+// ChargeCurrentLimit implements the api.VehicleCurrentLimit interface
+func (v *Provider) ChargeCurrentLimit() (int64, error) {
+	res, err := v.dataG()
+	if err != nil {
+		return 0, err
+	}
+	return int64(res.Response.ChargeState.ChargeCurrentRequest), nil
+}
+
 var _ api.Resurrector = (*Provider)(nil)
 
 // WakeUp implements the api.Resurrector interface
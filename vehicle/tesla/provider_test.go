@@ -0,0 +1,25 @@
+package tesla
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/evcc-io/tesla-proxy-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChargeCurrentLimit(t *testing.T) {
+	var res tesla.VehicleData
+	require.NoError(t, json.Unmarshal([]byte(`{"response": {"charge_state": {"charge_current_request": 10, "charge_current_request_max": 16}}}`), &res))
+
+	v := &Provider{
+		dataG: func() (*tesla.VehicleData, error) {
+			return &res, nil
+		},
+	}
+
+	limit, err := v.ChargeCurrentLimit()
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), limit)
+}
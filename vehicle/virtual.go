@@ -0,0 +1,73 @@
+package vehicle
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+)
+
+// Virtual is a vehicle without api. Its soc is estimated by the loadpoint from the charge power.
+type Virtual struct {
+	*embed
+	mu         sync.Mutex
+	soc        float64
+	initialSoc float64
+}
+
+func init() {
+	registry.Add("virtual", NewVirtualFromConfig)
+}
+
+// NewVirtualFromConfig creates a new virtual vehicle
+func NewVirtualFromConfig(other map[string]interface{}) (api.Vehicle, error) {
+	var cc struct {
+		embed `mapstructure:",squash"`
+		Soc   float64 // initial soc on connect, 0 = unknown
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Capacity_ <= 0 {
+		return nil, errors.New("missing capacity")
+	}
+
+	if cc.Soc < 0 || cc.Soc > 100 {
+		return nil, fmt.Errorf("invalid soc: %.0f", cc.Soc)
+	}
+
+	v := &Virtual{
+		embed:      &cc.embed,
+		soc:        cc.Soc,
+		initialSoc: cc.Soc,
+	}
+
+	return v, nil
+}
+
+// Soc implements the api.Vehicle interface. It returns the last estimated soc, zero if unknown.
+func (v *Virtual) Soc() (float64, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.soc, nil
+}
+
+var _ api.SocEstimate = (*Virtual)(nil)
+
+// SetSoc implements the api.SocEstimate interface
+func (v *Virtual) SetSoc(soc float64) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.soc = min(max(soc, 0), 100)
+}
+
+// ResetSoc implements the api.SocEstimate interface
+func (v *Virtual) ResetSoc() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.soc = v.initialSoc
+}